@@ -22,18 +22,23 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/crossplane/function-sdk-go/logging"
 
 	"github.com/upbound/controlplane-mcp-server/internal/bootcheck"
+	"github.com/upbound/controlplane-mcp-server/internal/cursor"
 	"github.com/upbound/controlplane-mcp-server/internal/tool"
 )
 
@@ -57,6 +62,13 @@ type Command struct {
 
 	Port       string `default:":8081" help:"Address to listen on."                                                                          short:"p"`
 	Kubeconfig string `default:""      help:"Location of the kubeconfig to use for the API clients. Default is to use the incluster config."`
+
+	CursorTTL time.Duration `default:"5m" env:"CURSOR_TTL" help:"How long paginated results are kept for after they were last read." name:"cursor-ttl"`
+
+	EnableTools  []string `env:"ENABLE_TOOLS"  help:"Tools or tool groups (pods, crossplane, generic, write) to enable. Default is to enable all tools." name:"enable-tools"  sep:","`
+	DisableTools []string `env:"DISABLE_TOOLS" help:"Tools or tool groups (pods, crossplane, generic, write) to disable. Takes precedence over enabled tools." name:"disable-tools" sep:","`
+
+	RenderFunctionAddresses []string `env:"RENDER_FUNCTION_ADDRESSES" help:"gRPC addresses (host:port) of composition functions render_composition may run. render_composition is unavailable unless at least one is set." name:"render-function-addresses" sep:","`
 }
 
 func main() {
@@ -68,14 +80,6 @@ func main() {
 		kong.UsageOnError(),
 	)
 
-	// initialize a new MCP server.
-	s := server.NewMCPServer(
-		desc,
-		version,
-		server.WithToolCapabilities(false),
-		server.WithRecovery(),
-	)
-
 	// specify logging options
 	zapOpts := []zap.Opts{}
 	if cmd.Debug {
@@ -96,10 +100,37 @@ func main() {
 	cs, err := kubernetes.NewForConfig(cfg)
 	kongCtx.FatalIfErrorf(err, "failed to construct clientset")
 
+	dyn, err := dynamic.NewForConfig(cfg)
+	kongCtx.FatalIfErrorf(err, "failed to construct dynamic client")
+
+	hc, err := rest.HTTPClientFor(cfg)
+	kongCtx.FatalIfErrorf(err, "failed to construct HTTP client")
+
+	mapper, err := apiutil.NewDynamicRESTMapper(cfg, hc)
+	kongCtx.FatalIfErrorf(err, "failed to construct REST mapper")
+
 	// Set up tools and corresponding handlers.
-	ts := tool.NewServer(cs, tool.WithLogging(log))
-	s.AddTool(tool.GetPodLogs(), ts.GetPodLogsHander)
-	s.AddTool(tool.GetPodEvents(), ts.GetPodEventsHander)
+	ts := tool.NewServer(cs, dyn, mapper,
+		tool.WithLogging(log),
+		tool.WithCursorStore(cursor.NewStore(cursor.WithTTL(cmd.CursorTTL))),
+		tool.WithRenderFunctionAddresses(cmd.RenderFunctionAddresses...),
+	)
+	reg := tool.NewRegistry(
+		tool.WithEnabled(cmd.EnableTools...),
+		tool.WithDisabled(cmd.DisableTools...),
+	)
+	reg.Register(ts.Entries()...)
+	kongCtx.FatalIfErrorf(reg.Validate(), "invalid tool selection")
+
+	// initialize a new MCP server.
+	s := server.NewMCPServer(
+		desc,
+		version,
+		server.WithToolCapabilities(false),
+		server.WithRecovery(),
+		server.WithInstructions(reg.Instructions()),
+	)
+	s.AddTools(reg.Tools()...)
 
 	ss := server.NewStreamableHTTPServer(s)
 
@@ -0,0 +1,207 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package budget provides helpers for shaping tool responses so that they fit
+within a caller supplied token or byte budget.
+*/
+package budget
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// bytesPerToken is a rough estimate of the number of bytes that make up
+	// a single token for typical log and JSON content.
+	bytesPerToken = 4
+	// noticeReserve is the number of bytes held back from the budget for the
+	// truncation notice.
+	noticeReserve = 256
+)
+
+// EstimateTokens returns a rough estimate of the number of tokens in the
+// supplied text.
+func EstimateTokens(text string) int {
+	return (len(text) + bytesPerToken - 1) / bytesPerToken
+}
+
+// Budget is the maximum size of a response. The zero value is unlimited.
+type Budget struct {
+	maxBytes int
+}
+
+// New constructs a Budget from the supplied maximum number of tokens and
+// bytes. Values less than or equal to zero are ignored. If both are supplied
+// the smaller of the two wins.
+func New(maxTokens, maxBytes int) Budget {
+	b := Budget{}
+	if maxTokens > 0 {
+		b.maxBytes = maxTokens * bytesPerToken
+	}
+	if maxBytes > 0 && (b.maxBytes == 0 || maxBytes < b.maxBytes) {
+		b.maxBytes = maxBytes
+	}
+	return b
+}
+
+// Unlimited reports whether the Budget places no limit on the response.
+func (b Budget) Unlimited() bool {
+	return b.maxBytes <= 0
+}
+
+// MaxBytes returns the maximum number of bytes allowed by the Budget, or zero
+// if the Budget is unlimited.
+func (b Budget) MaxBytes() int {
+	return b.maxBytes
+}
+
+// Fits reports whether the supplied text fits within the Budget.
+func (b Budget) Fits(text string) bool {
+	return b.Unlimited() || len(text) <= b.maxBytes
+}
+
+// available returns the number of bytes available for content once room for
+// the truncation notice has been reserved.
+func (b Budget) available() int {
+	return max(b.maxBytes-noticeReserve, b.maxBytes/2)
+}
+
+// Truncation describes content that was dropped to fit within a Budget.
+type Truncation struct {
+	// Unit being counted, e.g. lines or events.
+	Unit string
+	// Total number of units before truncation.
+	Total int
+	// Kept number of units after truncation.
+	Kept int
+	// Strategy used to pick the units that were kept.
+	Strategy string
+}
+
+// String renders the Truncation as a notice suitable for appending to a tool
+// result.
+func (t *Truncation) String() string {
+	return fmt.Sprintf("[truncated: showing %d of %d %s (%s) to fit the response budget; "+
+		"raise maxTokens/maxBytes or narrow the request to see more]", t.Kept, t.Total, t.Unit, t.Strategy)
+}
+
+// Lines keeps the head and tail of the supplied text, dropping lines from the
+// middle until it fits within the Budget. Text made up of a single oversized
+// line is cut at the byte level instead.
+func (b Budget) Lines(text string) (string, *Truncation) {
+	if b.Fits(text) {
+		return text, nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	avail := b.available()
+
+	head, size := 0, 0
+	for head < len(lines) && size+len(lines[head])+1 <= avail/2 {
+		size += len(lines[head]) + 1
+		head++
+	}
+	tail := len(lines)
+	for tail > head && size+len(lines[tail-1])+1 <= avail {
+		size += len(lines[tail-1]) + 1
+		tail--
+	}
+
+	if head == 0 && tail == len(lines) {
+		half := avail / 2
+		cut := strings.ToValidUTF8(text[:half], "") + "\n...\n" + strings.ToValidUTF8(text[len(text)-half:], "")
+		return cut, &Truncation{Unit: "bytes", Total: len(text), Kept: 2 * half, Strategy: "head and tail"}
+	}
+
+	kept := make([]string, 0, head+len(lines)-tail+1)
+	kept = append(kept, lines[:head]...)
+	kept = append(kept, fmt.Sprintf("... %d lines omitted ...", tail-head))
+	kept = append(kept, lines[tail:]...)
+
+	return strings.Join(kept, "\n"), &Truncation{
+		Unit:     "lines",
+		Total:    len(lines),
+		Kept:     len(lines) - (tail - head),
+		Strategy: "head and tail",
+	}
+}
+
+// Tail keeps the last items that fit within the Budget. Items are expected to
+// be ordered from oldest to most recent so that the most recent are kept.
+func (b Budget) Tail(unit string, items []string) ([]string, *Truncation) {
+	if b.Fits(strings.Join(items, "\n")) {
+		return items, nil
+	}
+
+	avail := b.available()
+	start, size := len(items), 0
+	for start > 0 && size+len(items[start-1])+1 <= avail {
+		size += len(items[start-1]) + 1
+		start--
+	}
+
+	return items[start:], &Truncation{
+		Unit:     unit,
+		Total:    len(items),
+		Kept:     len(items) - start,
+		Strategy: "most recent",
+	}
+}
+
+// Prioritized keeps the highest priority items that fit within the Budget,
+// preserving their original order. Higher values returned by priority are
+// kept first.
+func (b Budget) Prioritized(unit string, items []string, priority func(i int) int) ([]string, *Truncation) {
+	if b.Fits(strings.Join(items, "\n")) {
+		return items, nil
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, c int) int {
+		return priority(c) - priority(a)
+	})
+
+	avail := b.available()
+	keep := make([]bool, len(items))
+	size := 0
+	for _, i := range order {
+		if size+len(items[i])+1 > avail {
+			continue
+		}
+		size += len(items[i]) + 1
+		keep[i] = true
+	}
+
+	kept := make([]string, 0, len(items))
+	for i, k := range keep {
+		if k {
+			kept = append(kept, items[i])
+		}
+	}
+
+	return kept, &Truncation{
+		Unit:     unit,
+		Total:    len(items),
+		Kept:     len(kept),
+		Strategy: "highest severity",
+	}
+}
@@ -0,0 +1,183 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package budget
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func lines(prefix string, n int) []string {
+	l := make([]string, 0, n)
+	for i := range n {
+		l = append(l, fmt.Sprintf("%s-%03d", prefix, i))
+	}
+	return l
+}
+
+func TestNew(t *testing.T) {
+	type args struct {
+		maxTokens int
+		maxBytes  int
+	}
+	type want struct {
+		maxBytes int
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Unlimited": {
+			reason: "If no limits are supplied the budget should be unlimited.",
+			args:   args{},
+			want:   want{},
+		},
+		"Tokens": {
+			reason: "A token limit should be converted into an estimated byte limit.",
+			args:   args{maxTokens: 100},
+			want:   want{maxBytes: 400},
+		},
+		"SmallestWins": {
+			reason: "If both limits are supplied the smaller one should win.",
+			args:   args{maxTokens: 100, maxBytes: 50},
+			want:   want{maxBytes: 50},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := New(tc.args.maxTokens, tc.args.maxBytes).MaxBytes()
+			if diff := cmp.Diff(tc.want.maxBytes, got); diff != "" {
+				t.Errorf("\n%s\nNew(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	long := strings.Join(lines("line", 200), "\n")
+
+	type args struct {
+		b    Budget
+		text string
+	}
+	type want struct {
+		head      string
+		tail      string
+		truncated bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Fits": {
+			reason: "Text that fits within the budget should be returned as is.",
+			args:   args{b: New(0, 4096), text: "a\nb\n"},
+			want:   want{head: "a\nb\n", tail: "a\nb\n"},
+		},
+		"HeadAndTail": {
+			reason: "Text that exceeds the budget should keep its first and last lines.",
+			args:   args{b: New(0, 1024), text: long},
+			want:   want{head: "line-000", tail: "line-199", truncated: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, tr := tc.args.b.Lines(tc.args.text)
+
+			if diff := cmp.Diff(tc.want.truncated, tr != nil); diff != "" {
+				t.Errorf("\n%s\nLines(...): -want truncated, +got truncated:\n%s", tc.reason, diff)
+			}
+			if !strings.HasPrefix(got, tc.want.head) || !strings.HasSuffix(got, tc.want.tail) {
+				t.Errorf("\n%s\nLines(...): want head %q and tail %q, got:\n%s", tc.reason, tc.want.head, tc.want.tail, got)
+			}
+			if !tc.args.b.Fits(got) {
+				t.Errorf("\n%s\nLines(...): result of %d bytes exceeds budget of %d", tc.reason, len(got), tc.args.b.MaxBytes())
+			}
+		})
+	}
+}
+
+func TestTail(t *testing.T) {
+	type args struct {
+		b     Budget
+		items []string
+	}
+	type want struct {
+		first string
+		last  string
+		kept  int
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Fits": {
+			reason: "Items that fit within the budget should all be kept.",
+			args:   args{b: New(0, 1024), items: lines("event", 3)},
+			want:   want{first: "event-000", last: "event-002", kept: 3},
+		},
+		"MostRecent": {
+			reason: "Items that exceed the budget should keep the most recent.",
+			args:   args{b: New(0, 512), items: lines("event", 100)},
+			want:   want{first: "event-075", last: "event-099", kept: 25},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, _ := tc.args.b.Tail("events", tc.args.items)
+
+			if diff := cmp.Diff(tc.want.kept, len(got)); diff != "" {
+				t.Errorf("\n%s\nTail(...): -want kept, +got kept:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff([]string{tc.want.first, tc.want.last}, []string{got[0], got[len(got)-1]}); diff != "" {
+				t.Errorf("\n%s\nTail(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPrioritized(t *testing.T) {
+	items := []string{"Ready=True: available and reconciled", "Synced=False", "Healthy=True: package is healthy", "Installed=False"}
+	severity := func(i int) int {
+		if strings.HasSuffix(items[i], "False") {
+			return 1
+		}
+		return 0
+	}
+
+	got, tr := New(0, 70).Prioritized("conditions", items, severity)
+
+	want := []string{"Synced=False", "Installed=False"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Prioritized(...): -want, +got:\n%s", diff)
+	}
+	if tr == nil || tr.Kept != 2 || tr.Total != 4 {
+		t.Errorf("Prioritized(...): unexpected truncation %+v", tr)
+	}
+}
@@ -0,0 +1,181 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package cursor provides opaque continuation cursors backed by server-side
+snapshots of tool results.
+*/
+package cursor
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	// defaultTTL is how long a snapshot is kept after it was last read.
+	defaultTTL = 5 * time.Minute
+	// defaultMaxSnapshots is the maximum number of snapshots held at once.
+	defaultMaxSnapshots = 128
+
+	errMalformed = "malformed cursor"
+	errExpired   = "cursor has expired, call the tool again without a cursor"
+	errMismatch  = "cursor was returned for a different request, call the tool again without a cursor"
+)
+
+// Store holds snapshots of results so that they can be paged through
+// deterministically.
+type Store struct {
+	mu        sync.Mutex
+	snapshots map[string]*snapshot
+
+	ttl          time.Duration
+	maxSnapshots int
+	now          func() time.Time
+}
+
+type snapshot struct {
+	origin  string
+	items   any
+	expires time.Time
+}
+
+// Option modifies the underlying Store.
+type Option func(*Store)
+
+// WithTTL overrides the default snapshot TTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Store) {
+		s.ttl = ttl
+	}
+}
+
+// WithMaxSnapshots overrides the default maximum number of snapshots.
+func WithMaxSnapshots(m int) Option {
+	return func(s *Store) {
+		s.maxSnapshots = m
+	}
+}
+
+// WithClock overrides the clock used to expire snapshots.
+func WithClock(now func() time.Time) Option {
+	return func(s *Store) {
+		s.now = now
+	}
+}
+
+// NewStore constructs a new Store.
+func NewStore(opts ...Option) *Store {
+	s := &Store{
+		snapshots:    map[string]*snapshot{},
+		ttl:          defaultTTL,
+		maxSnapshots: defaultMaxSnapshots,
+		now:          time.Now,
+	}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	return s
+}
+
+// Put stores a snapshot of the supplied items and returns its ID. The origin
+// identifies the request the items were read for, e.g. the tool and the
+// object it was called on; the snapshot is only returned to requests with the
+// same origin.
+func (s *Store) Put(origin string, items any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict()
+
+	// Make room by dropping the snapshot closest to expiry.
+	if len(s.snapshots) >= s.maxSnapshots {
+		oldest := ""
+		for id, snap := range s.snapshots {
+			if oldest == "" || snap.expires.Before(s.snapshots[oldest].expires) {
+				oldest = id
+			}
+		}
+		delete(s.snapshots, oldest)
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+
+	s.snapshots[id] = &snapshot{origin: origin, items: items, expires: s.now().Add(s.ttl)}
+	return id
+}
+
+// Get returns the items of the snapshot with the supplied ID, extending its
+// TTL. Snapshots stored for a different origin are rejected.
+func (s *Store) Get(id, origin string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict()
+
+	snap, ok := s.snapshots[id]
+	if !ok {
+		return nil, errors.New(errExpired)
+	}
+	if snap.origin != origin {
+		return nil, errors.New(errMismatch)
+	}
+	snap.expires = s.now().Add(s.ttl)
+	return snap.items, nil
+}
+
+// evict expired snapshots. Callers must hold the lock.
+func (s *Store) evict() {
+	now := s.now()
+	for id, snap := range s.snapshots {
+		if now.After(snap.expires) {
+			delete(s.snapshots, id)
+		}
+	}
+}
+
+// Encode the supplied snapshot ID and offset into an opaque cursor.
+func Encode(id string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id + ":" + strconv.Itoa(offset)))
+}
+
+// Decode an opaque cursor into its snapshot ID and offset.
+func Decode(c string) (string, int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return "", 0, errors.Wrap(err, errMalformed)
+	}
+	id, off, ok := strings.Cut(string(b), ":")
+	if !ok || id == "" {
+		return "", 0, errors.New(errMalformed)
+	}
+	offset, err := strconv.Atoi(off)
+	if err != nil || offset < 0 {
+		return "", 0, errors.New(errMalformed)
+	}
+	return id, offset, nil
+}
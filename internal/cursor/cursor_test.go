@@ -0,0 +1,130 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestStore(t *testing.T) {
+	type want struct {
+		items any
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		elapsed time.Duration
+		origin  string
+		want    want
+	}{
+		"Fresh": {
+			reason:  "A snapshot read within its TTL should be returned.",
+			elapsed: time.Minute,
+			origin:  "get_pod_logs default/a",
+			want: want{
+				items: []string{"a", "b"},
+			},
+		},
+		"Expired": {
+			reason:  "A snapshot read after its TTL should be reported as expired.",
+			elapsed: 2 * time.Hour,
+			origin:  "get_pod_logs default/a",
+			want: want{
+				err: errors.New(errExpired),
+			},
+		},
+		"OtherOrigin": {
+			reason:  "A snapshot read for a different origin should be rejected.",
+			elapsed: time.Minute,
+			origin:  "get_pod_logs default/b",
+			want: want{
+				err: errors.New(errMismatch),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			s := NewStore(WithTTL(time.Hour), WithClock(func() time.Time { return now }))
+			id := s.Put("get_pod_logs default/a", []string{"a", "b"})
+
+			now = now.Add(tc.elapsed)
+			got, err := s.Get(id, tc.origin)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.items, got); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	type want struct {
+		id     string
+		offset int
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cursor string
+		want   want
+	}{
+		"RoundTrip": {
+			reason: "An encoded cursor should decode to its ID and offset.",
+			cursor: Encode("abc", 42),
+			want: want{
+				id:     "abc",
+				offset: 42,
+			},
+		},
+		"Malformed": {
+			reason: "A cursor that wasn't produced by Encode should be rejected.",
+			cursor: "not-a-cursor",
+			want: want{
+				err: errors.New(errMalformed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, offset, err := Decode(tc.cursor)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDecode(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, id); diff != "" {
+				t.Errorf("\n%s\nDecode(...): -want id, +got id:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.offset, offset); diff != "" {
+				t.Errorf("\n%s\nDecode(...): -want offset, +got offset:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package explain describes the schema of APIs served by CustomResourceDefinitions,
+like kubectl explain, along with the Crossplane composite and claim details of
+APIs defined by CompositeResourceDefinitions.
+*/
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// RoleComposite is the role of a composite resource API.
+	RoleComposite = "Composite"
+	// RoleClaim is the role of a claim API.
+	RoleClaim = "Claim"
+)
+
+// Explanation describes an API.
+type Explanation struct {
+	CRD      string   `json:"crd"`
+	Group    string   `json:"group"`
+	Kind     string   `json:"kind"`
+	Plural   string   `json:"plural"`
+	Scope    string   `json:"scope"`
+	Version  string   `json:"version"`
+	Versions []string `json:"servedVersions"`
+
+	// Role is Composite or Claim for APIs defined by an XRD.
+	Role       string      `json:"role,omitempty"`
+	Definition *Definition `json:"definition,omitempty"`
+
+	// Field is the explained field, or the whole API.
+	Field Field `json:"field"`
+	// Fields nested under the explained field, depth first.
+	Fields []Field `json:"fields"`
+}
+
+// Definition describes the CompositeResourceDefinition of an API.
+type Definition struct {
+	Name                 string               `json:"name"`
+	Composite            Names                `json:"composite"`
+	Claim                *Names               `json:"claim,omitempty"`
+	DefaultComposition   string               `json:"defaultComposition,omitempty"`
+	EnforcedComposition  string               `json:"enforcedComposition,omitempty"`
+	Compositions         []OfferedComposition `json:"compositions"`
+	ConnectionSecretKeys []string             `json:"connectionSecretKeys,omitempty"`
+}
+
+// OfferedComposition is a Composition that offers a composite type. Its
+// labels are what a compositionSelector can match to select it.
+type OfferedComposition struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Names of a composite or claim API.
+type Names struct {
+	Kind   string `json:"kind"`
+	Plural string `json:"plural"`
+}
+
+// Field is a field of an API's schema.
+type Field struct {
+	// Path of the field, e.g. spec.parameters.region. Empty for the root of
+	// the API.
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Enum        []any  `json:"enum,omitempty"`
+	Format      string `json:"format,omitempty"`
+	// OmittedFields is the number of direct nested fields not shown because
+	// they are deeper than the requested depth.
+	OmittedFields int `json:"omittedFields,omitempty"`
+}
+
+// Request identifies what to explain.
+type Request struct {
+	// Name of the API: a kind, plural, singular, short name, or the name of
+	// its CRD or XRD.
+	Name string
+	// Group disambiguates APIs with the same name. Optional.
+	Group string
+	// Version to explain. Defaults to the storage version.
+	Version string
+	// Field is a dot separated path to explain, e.g. spec.parameters.
+	// Optional.
+	Field string
+	// MaxDepth is the number of levels of nested fields to include. Zero
+	// means no limit.
+	MaxDepth int
+}
+
+// Explainer explains APIs.
+type Explainer struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Explainer.
+type Option func(*Explainer)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(e *Explainer) {
+		e.log = log
+	}
+}
+
+// New constructs a new Explainer.
+func New(obj *object.Client, opts ...Option) *Explainer {
+	e := &Explainer{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(e)
+	}
+
+	return e
+}
+
+// Explain the requested API.
+func (e *Explainer) Explain(ctx context.Context, r Request) (*Explanation, error) {
+	c, err := e.resolve(ctx, r.Name, r.Group)
+	if err != nil {
+		return nil, err
+	}
+	v, err := crd.Version(c, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+		return nil, errors.Errorf("version %s of CustomResourceDefinition %s has no schema", v.Name, c.GetName())
+	}
+
+	ex := &Explanation{
+		CRD:     c.GetName(),
+		Group:   c.Spec.Group,
+		Kind:    c.Spec.Names.Kind,
+		Plural:  c.Spec.Names.Plural,
+		Scope:   string(c.Spec.Scope),
+		Version: v.Name,
+		Fields:  []Field{},
+	}
+	for _, sv := range c.Spec.Versions {
+		if sv.Served {
+			ex.Versions = append(ex.Versions, sv.Name)
+		}
+	}
+
+	s, required, err := lookup(v.Schema.OpenAPIV3Schema, r.Field)
+	if err != nil {
+		return nil, err
+	}
+	depth := r.MaxDepth
+	if depth == 0 {
+		depth = -1
+	}
+	ex.Field = field(r.Field, s, required)
+	ex.Field.OmittedFields = nested(&ex.Fields, r.Field, s, depth)
+
+	if xrd := crd.DefinedBy(c); xrd != "" {
+		if ex.Definition, err = e.definition(ctx, xrd); err != nil {
+			return nil, err
+		}
+		ex.Role = RoleComposite
+		if ex.Definition.Claim != nil && ex.Definition.Claim.Kind == ex.Kind {
+			ex.Role = RoleClaim
+		}
+	}
+
+	return ex, nil
+}
+
+// resolve the CRD that serves the named API.
+func (e *Explainer) resolve(ctx context.Context, name, group string) (*extv1.CustomResourceDefinition, error) {
+	crds, err := crd.List(ctx, e.obj)
+	if err != nil {
+		return nil, err
+	}
+	n := strings.ToLower(name)
+	var matches []*extv1.CustomResourceDefinition
+	for _, c := range crds {
+		if group != "" && c.Spec.Group != group {
+			continue
+		}
+		names := append([]string{c.GetName(), c.Spec.Names.Kind, c.Spec.Names.Plural, c.Spec.Names.Singular}, c.Spec.Names.ShortNames...)
+		if slices.ContainsFunc(names, func(s string) bool { return strings.ToLower(s) == n }) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, errors.Errorf("no CustomResourceDefinition serves an API named %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		found := make([]string, 0, len(matches))
+		for _, c := range matches {
+			found = append(found, c.GetName())
+		}
+		return nil, errors.Errorf("%q is ambiguous, supply a group to choose between: %s", name, strings.Join(found, ", "))
+	}
+}
+
+// definition returns the named CompositeResourceDefinition and the
+// Compositions, with their labels, that offer its composite type.
+func (e *Explainer) definition(ctx context.Context, name string) (*Definition, error) {
+	xrd, err := e.obj.Get(ctx, composition.XRDGVK(), types.NamespacedName{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	pv := fieldpath.Pave(xrd.Object)
+	str := func(path string) string {
+		s, _ := pv.GetString(path)
+		return s
+	}
+
+	d := &Definition{
+		Name:                name,
+		Composite:           Names{Kind: str("spec.names.kind"), Plural: str("spec.names.plural")},
+		DefaultComposition:  str("spec.defaultCompositionRef.name"),
+		EnforcedComposition: str("spec.enforcedCompositionRef.name"),
+		Compositions:        []OfferedComposition{},
+	}
+	if k := str("spec.claimNames.kind"); k != "" {
+		d.Claim = &Names{Kind: k, Plural: str("spec.claimNames.plural")}
+	}
+	d.ConnectionSecretKeys, _ = pv.GetStringArray("spec.connectionSecretKeys")
+
+	comps, err := e.obj.List(ctx, composition.CompositionGVK(), "", metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	group := str("spec.group")
+	for _, c := range comps {
+		if offers(&c, group, d.Composite.Kind) {
+			d.Compositions = append(d.Compositions, OfferedComposition{Name: c.GetName(), Labels: c.GetLabels()})
+		}
+	}
+	slices.SortFunc(d.Compositions, func(a, b OfferedComposition) int { return strings.Compare(a.Name, b.Name) })
+	return d, nil
+}
+
+// offers reports whether the Composition composes the supplied composite
+// kind.
+func offers(c *unstructured.Unstructured, group, kind string) bool {
+	pv := fieldpath.Pave(c.Object)
+	av, _ := pv.GetString("spec.compositeTypeRef.apiVersion")
+	k, _ := pv.GetString("spec.compositeTypeRef.kind")
+	gv, err := schema.ParseGroupVersion(av)
+	return err == nil && gv.Group == group && k == kind
+}
+
+// lookup returns the schema of the field at the supplied dot separated path
+// and whether the field is required. Arrays and maps are traversed
+// transparently, as kubectl explain does.
+func lookup(s *extv1.JSONSchemaProps, path string) (*extv1.JSONSchemaProps, bool, error) {
+	if path == "" {
+		return s, false, nil
+	}
+	required := false
+	segs := strings.Split(path, ".")
+	for i, seg := range segs {
+		s = element(s)
+		p, ok := s.Properties[seg]
+		if !ok {
+			return nil, false, errors.Errorf("field %q does not exist", strings.Join(segs[:i+1], "."))
+		}
+		required = slices.Contains(s.Required, seg)
+		s = &p
+	}
+	return s, required, nil
+}
+
+// element returns the schema of the elements of an array or map, or the
+// supplied schema if it is neither.
+func element(s *extv1.JSONSchemaProps) *extv1.JSONSchemaProps {
+	switch {
+	case s.Type == "array" && s.Items != nil && s.Items.Schema != nil:
+		return element(s.Items.Schema)
+	case s.Type == "object" && len(s.Properties) == 0 && s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+		return element(s.AdditionalProperties.Schema)
+	default:
+		return s
+	}
+}
+
+// field converts the schema of the field at the supplied path.
+func field(path string, s *extv1.JSONSchemaProps, required bool) Field {
+	f := Field{
+		Path:        path,
+		Type:        typeName(s),
+		Description: s.Description,
+		Required:    required,
+		Format:      s.Format,
+		Default:     value(s.Default),
+	}
+	for _, e := range s.Enum {
+		f.Enum = append(f.Enum, value(&e))
+	}
+	return f
+}
+
+// nested appends the fields nested under the supplied schema to out, depth
+// first, up to the supplied number of levels. A negative depth means no
+// limit. It returns the number of direct nested fields omitted because the
+// depth was exhausted.
+func nested(out *[]Field, path string, s *extv1.JSONSchemaProps, depth int) int {
+	el := element(s)
+	if len(el.Properties) == 0 {
+		return 0
+	}
+	if depth == 0 {
+		return len(el.Properties)
+	}
+	for _, n := range slices.Sorted(maps.Keys(el.Properties)) {
+		p := el.Properties[n]
+		fp := n
+		if path != "" {
+			fp = path + "." + n
+		}
+		*out = append(*out, field(fp, &p, slices.Contains(el.Required, n)))
+		i := len(*out) - 1
+		(*out)[i].OmittedFields = nested(out, fp, &p, depth-1)
+	}
+	return 0
+}
+
+// typeName returns a type name for the supplied schema in the style of
+// kubectl explain, e.g. []string or map[string]object.
+func typeName(s *extv1.JSONSchemaProps) string {
+	switch {
+	case s.XIntOrString:
+		return "int-or-string"
+	case s.Type == "array" && s.Items != nil && s.Items.Schema != nil:
+		return "[]" + typeName(s.Items.Schema)
+	case s.Type == "object" && len(s.Properties) == 0 && s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+		return "map[string]" + typeName(s.AdditionalProperties.Schema)
+	case s.Type == "" && s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields:
+		return "object"
+	case s.Type == "":
+		return "any"
+	default:
+		return s.Type
+	}
+}
+
+// value decodes a JSON value from a schema.
+func value(j *extv1.JSON) any {
+	if j == nil || len(j.Raw) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(j.Raw, &v); err != nil {
+		return string(j.Raw)
+	}
+	return v
+}
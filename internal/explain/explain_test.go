@@ -0,0 +1,227 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package explain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+func postgresSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"spec": map[string]any{
+				"type":     "object",
+				"required": []any{"parameters"},
+				"properties": map[string]any{
+					"parameters": map[string]any{
+						"type":        "object",
+						"description": "Database parameters.",
+						"required":    []any{"storageGB"},
+						"properties": map[string]any{
+							"storageGB": map[string]any{"type": "integer", "description": "Storage in GB."},
+							"version":   map[string]any{"type": "string", "enum": []any{"15", "16"}, "default": "16"},
+							"tags":      map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+						},
+					},
+					"users": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "string"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func postgresCRD(name, kind, plural, scope string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]any{
+			"name": name,
+			"ownerReferences": []any{map[string]any{
+				"apiVersion": "apiextensions.crossplane.io/v1",
+				"kind":       "CompositeResourceDefinition",
+				"name":       "xpostgres.example.org",
+				"uid":        "xrd-uid",
+			}},
+		},
+		"spec": map[string]any{
+			"group": "example.org",
+			"names": map[string]any{"kind": kind, "plural": plural},
+			"scope": scope,
+			"versions": []any{map[string]any{
+				"name":    "v1alpha1",
+				"served":  true,
+				"storage": true,
+				"schema":  map[string]any{"openAPIV3Schema": postgresSchema()},
+			}},
+		},
+	}}
+}
+
+func objects() []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{
+		postgresCRD("xpostgres.example.org", "XPostgres", "xpostgres", "Cluster"),
+		postgresCRD("postgres.example.org", "Postgres", "postgres", "Namespaced"),
+		{Object: map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "CompositeResourceDefinition",
+			"metadata":   map[string]any{"name": "xpostgres.example.org"},
+			"spec": map[string]any{
+				"group":                 "example.org",
+				"names":                 map[string]any{"kind": "XPostgres", "plural": "xpostgres"},
+				"claimNames":            map[string]any{"kind": "Postgres", "plural": "postgres"},
+				"defaultCompositionRef": map[string]any{"name": "xpostgres-aws"},
+				"connectionSecretKeys":  []any{"username", "password"},
+			},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "Composition",
+			"metadata":   map[string]any{"name": "xpostgres-aws", "labels": map[string]any{"provider": "aws"}},
+			"spec":       map[string]any{"compositeTypeRef": map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "XPostgres"}},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "Composition",
+			"metadata":   map[string]any{"name": "xbucket-aws"},
+			"spec":       map[string]any{"compositeTypeRef": map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "XBucket"}},
+		}},
+	}
+}
+
+func TestExplain(t *testing.T) {
+	definition := &Definition{
+		Name:                 "xpostgres.example.org",
+		Composite:            Names{Kind: "XPostgres", Plural: "xpostgres"},
+		Claim:                &Names{Kind: "Postgres", Plural: "postgres"},
+		DefaultComposition:   "xpostgres-aws",
+		Compositions:         []OfferedComposition{{Name: "xpostgres-aws", Labels: map[string]string{"provider": "aws"}}},
+		ConnectionSecretKeys: []string{"username", "password"},
+	}
+
+	type want struct {
+		ex  *Explanation
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    Request
+		want   want
+	}{
+		"Claim": {
+			reason: "A claim should be explained to the requested depth along with its XRD.",
+			req:    Request{Name: "postgres", MaxDepth: 2},
+			want: want{
+				ex: &Explanation{
+					CRD:        "postgres.example.org",
+					Group:      "example.org",
+					Kind:       "Postgres",
+					Plural:     "postgres",
+					Scope:      "Namespaced",
+					Version:    "v1alpha1",
+					Versions:   []string{"v1alpha1"},
+					Role:       RoleClaim,
+					Definition: definition,
+					Field:      Field{Type: "object"},
+					Fields: []Field{
+						{Path: "spec", Type: "object"},
+						{Path: "spec.parameters", Type: "object", Description: "Database parameters.", Required: true, OmittedFields: 3},
+						{Path: "spec.users", Type: "[]object", OmittedFields: 1},
+					},
+				},
+			},
+		},
+		"Field": {
+			reason: "A field path should be explained with types, defaults, enums and required fields.",
+			req:    Request{Name: "XPostgres", Field: "spec.parameters"},
+			want: want{
+				ex: &Explanation{
+					CRD:        "xpostgres.example.org",
+					Group:      "example.org",
+					Kind:       "XPostgres",
+					Plural:     "xpostgres",
+					Scope:      "Cluster",
+					Version:    "v1alpha1",
+					Versions:   []string{"v1alpha1"},
+					Role:       RoleComposite,
+					Definition: definition,
+					Field:      Field{Path: "spec.parameters", Type: "object", Description: "Database parameters.", Required: true},
+					Fields: []Field{
+						{Path: "spec.parameters.storageGB", Type: "integer", Description: "Storage in GB.", Required: true},
+						{Path: "spec.parameters.tags", Type: "map[string]string"},
+						{Path: "spec.parameters.version", Type: "string", Default: "16", Enum: []any{"15", "16"}},
+					},
+				},
+			},
+		},
+		"ArrayField": {
+			reason: "Arrays should be traversed transparently.",
+			req:    Request{Name: "xpostgres.example.org", Field: "spec.users.name"},
+			want: want{
+				ex: &Explanation{
+					CRD:        "xpostgres.example.org",
+					Group:      "example.org",
+					Kind:       "XPostgres",
+					Plural:     "xpostgres",
+					Scope:      "Cluster",
+					Version:    "v1alpha1",
+					Versions:   []string{"v1alpha1"},
+					Role:       RoleComposite,
+					Definition: definition,
+					Field:      Field{Path: "spec.users.name", Type: "string"},
+					Fields:     []Field{},
+				},
+			},
+		},
+		"NoSuchField": {
+			reason: "An error should be returned for fields that don't exist.",
+			req:    Request{Name: "XPostgres", Field: "spec.nope"},
+			want:   want{err: true},
+		},
+		"NotFound": {
+			reason: "An error should be returned for APIs that don't exist.",
+			req:    Request{Name: "XBucket"},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := New(objectfake.NewClient([]objectfake.Kind{{GVK: composition.XRDGVK()}}, objects()))
+
+			got, err := e.Explain(context.Background(), tc.req)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nExplain(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.ex, got); diff != "" {
+				t.Errorf("\n%s\nExplain(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
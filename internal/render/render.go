@@ -0,0 +1,343 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package render runs a Composition's function pipeline against a composite
+resource without applying anything, like the crossplane render command.
+*/
+package render
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+const (
+	// AnnotationCompositionResourceName is the annotation Crossplane uses to
+	// identify a composed resource within its Composition.
+	AnnotationCompositionResourceName = "crossplane.io/composition-resource-name"
+	// LabelComposite is the label Crossplane uses to identify the composite
+	// resource that owns a composed resource.
+	LabelComposite = "crossplane.io/composite"
+
+	// maxRequirementsIterations is the maximum number of times a function is
+	// called while its extra resource requirements stabilize. It matches
+	// Crossplane's own limit.
+	maxRequirementsIterations = 5
+)
+
+// A Runner runs a composition function.
+type Runner interface {
+	// RunFunction runs the named function with the supplied request.
+	RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error)
+}
+
+// A Fetcher fetches the extra resources a function requires.
+type Fetcher interface {
+	// Fetch the resources matched by the supplied selector.
+	Fetch(ctx context.Context, sel *fnv1.ResourceSelector) ([]*unstructured.Unstructured, error)
+}
+
+// Inputs to a render.
+type Inputs struct {
+	// CompositeResource to render.
+	CompositeResource *unstructured.Unstructured
+	// Composition whose pipeline is run.
+	Composition *unstructured.Unstructured
+	// ObservedResources are the composed resources that already exist.
+	ObservedResources []*unstructured.Unstructured
+	// Context is the initial pipeline context.
+	Context map[string]any
+}
+
+// Outputs of a render.
+type Outputs struct {
+	CompositeResource map[string]any     `json:"compositeResource"`
+	ComposedResources []ComposedResource `json:"composedResources"`
+	Results           []Result           `json:"results"`
+	Context           map[string]any     `json:"context,omitempty"`
+	// Error that stopped the pipeline, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ComposedResource is a desired composed resource.
+type ComposedResource struct {
+	Name     string         `json:"name"`
+	Ready    string         `json:"ready,omitempty"`
+	Resource map[string]any `json:"resource"`
+}
+
+// Result is a result returned by a pipeline step.
+type Result struct {
+	Step     string `json:"step"`
+	Severity string `json:"severity"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message"`
+}
+
+// pipelineStep is a step of a Composition's pipeline as stored in the API.
+type pipelineStep struct {
+	Step        string `json:"step"`
+	FunctionRef struct {
+		Name string `json:"name"`
+	} `json:"functionRef"`
+	Input       map[string]any `json:"input"`
+	Credentials []struct {
+		Name string `json:"name"`
+	} `json:"credentials"`
+}
+
+// Renderer renders composite resources.
+type Renderer struct {
+	log     logging.Logger
+	runner  Runner
+	fetcher Fetcher
+}
+
+// Option modifies the underlying Renderer.
+type Option func(*Renderer)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(r *Renderer) {
+		r.log = log
+	}
+}
+
+// WithFetcher supplies a Fetcher for the extra resources functions require.
+// Without one, extra resource requirements are reported as warnings.
+func WithFetcher(f Fetcher) Option {
+	return func(r *Renderer) {
+		r.fetcher = f
+	}
+}
+
+// New constructs a new Renderer that runs functions with the supplied Runner.
+func New(runner Runner, opts ...Option) *Renderer {
+	r := &Renderer{
+		log:    logging.NewNopLogger(),
+		runner: runner,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// Render runs the Composition's pipeline against the composite resource and
+// returns the desired state. A fatal result or a failure to run a function
+// stops the pipeline; the outputs gathered so far are returned along with
+// the error.
+func (r *Renderer) Render(ctx context.Context, in Inputs) (*Outputs, error) {
+	var steps []pipelineStep
+	if err := fieldpath.Pave(in.Composition.Object).GetValueInto("spec.pipeline", &steps); err != nil {
+		return nil, errors.Wrap(err, "cannot read the pipeline of the Composition; only Pipeline mode Compositions can be rendered")
+	}
+
+	xr, err := structpb.NewStruct(in.CompositeResource.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert the composite resource")
+	}
+	observed := &fnv1.State{Composite: &fnv1.Resource{Resource: xr}, Resources: map[string]*fnv1.Resource{}}
+	for _, o := range in.ObservedResources {
+		name := o.GetAnnotations()[AnnotationCompositionResourceName]
+		if name == "" {
+			return nil, errors.Errorf("observed resource %s %s has no %s annotation", o.GetKind(), o.GetName(), AnnotationCompositionResourceName)
+		}
+		s, err := structpb.NewStruct(o.Object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot convert observed resource %s", name)
+		}
+		observed.Resources[name] = &fnv1.Resource{Resource: s}
+	}
+
+	fctx, err := structpb.NewStruct(in.Context)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert the pipeline context")
+	}
+
+	out := &Outputs{Results: []Result{}}
+	desired := &fnv1.State{}
+
+	for _, s := range steps {
+		req := &fnv1.RunFunctionRequest{Observed: observed, Desired: desired, Context: fctx}
+		if s.Input != nil {
+			if req.Input, err = structpb.NewStruct(s.Input); err != nil {
+				return nil, errors.Wrapf(err, "cannot convert the input of step %q", s.Step)
+			}
+		}
+		for _, c := range s.Credentials {
+			out.Results = append(out.Results, Result{
+				Step:     s.Step,
+				Severity: "Warning",
+				Message:  fmt.Sprintf("credentials %q are not supplied when rendering", c.Name),
+			})
+		}
+
+		rsp, err := r.run(ctx, s, req, out)
+		if err != nil {
+			out.Error = errors.Wrapf(err, "cannot run pipeline step %q", s.Step).Error()
+			break
+		}
+
+		fatal := false
+		for _, rs := range rsp.GetResults() {
+			out.Results = append(out.Results, result(s.Step, rs))
+			fatal = fatal || rs.GetSeverity() == fnv1.Severity_SEVERITY_FATAL
+		}
+		if fatal {
+			out.Error = fmt.Sprintf("pipeline step %q returned a fatal result", s.Step)
+			break
+		}
+
+		desired = rsp.GetDesired()
+		if rsp.GetContext() != nil {
+			fctx = rsp.GetContext()
+		}
+	}
+
+	out.CompositeResource = composite(in.CompositeResource, desired.GetComposite())
+	out.ComposedResources = composed(in.CompositeResource, desired.GetResources())
+	out.Context = fctx.AsMap()
+
+	if out.Error != "" {
+		return out, errors.New(out.Error)
+	}
+	return out, nil
+}
+
+// run a single step, calling the function again with the extra resources it
+// requires until its requirements stabilize.
+func (r *Renderer) run(ctx context.Context, s pipelineStep, req *fnv1.RunFunctionRequest, out *Outputs) (*fnv1.RunFunctionResponse, error) {
+	var prev *fnv1.Requirements
+	for range maxRequirementsIterations {
+		r.log.Debug("Running pipeline step", "step", s.Step, "function", s.FunctionRef.Name)
+		rsp, err := r.runner.RunFunction(ctx, s.FunctionRef.Name, req)
+		if err != nil {
+			return nil, err
+		}
+
+		reqs := rsp.GetRequirements()
+		if len(reqs.GetExtraResources()) == 0 || proto.Equal(reqs, prev) {
+			return rsp, nil
+		}
+		prev = reqs
+
+		if r.fetcher == nil {
+			out.Results = append(out.Results, Result{
+				Step:     s.Step,
+				Severity: "Warning",
+				Message:  "function requires extra resources but none can be fetched",
+			})
+			return rsp, nil
+		}
+
+		req.ExtraResources = map[string]*fnv1.Resources{}
+		for _, name := range slices.Sorted(maps.Keys(reqs.GetExtraResources())) {
+			objs, err := r.fetcher.Fetch(ctx, reqs.GetExtraResources()[name])
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot fetch extra resources %q", name)
+			}
+			rs := &fnv1.Resources{}
+			for _, o := range objs {
+				st, err := structpb.NewStruct(o.Object)
+				if err != nil {
+					return nil, errors.Wrapf(err, "cannot convert extra resource %s", o.GetName())
+				}
+				rs.Items = append(rs.Items, &fnv1.Resource{Resource: st})
+			}
+			req.ExtraResources[name] = rs
+		}
+	}
+	return nil, errors.Errorf("extra resource requirements did not stabilize after %d calls", maxRequirementsIterations)
+}
+
+// result converts a function result.
+func result(step string, rs *fnv1.Result) Result {
+	sev := strings.TrimPrefix(rs.GetSeverity().String(), "SEVERITY_")
+	return Result{
+		Step:     step,
+		Severity: strings.ToUpper(sev[:1]) + strings.ToLower(sev[1:]),
+		Reason:   rs.GetReason(),
+		Message:  rs.GetMessage(),
+	}
+}
+
+// composite returns the supplied composite resource with the status from the
+// desired composite resource merged in.
+func composite(xr *unstructured.Unstructured, desired *fnv1.Resource) map[string]any {
+	out := xr.DeepCopy().Object
+	st, ok := desired.GetResource().AsMap()["status"].(map[string]any)
+	if !ok {
+		return out
+	}
+	status, _ := out["status"].(map[string]any)
+	if status == nil {
+		status = map[string]any{}
+	}
+	maps.Copy(status, st)
+	out["status"] = status
+	return out
+}
+
+// composed returns the desired composed resources, labelled and annotated
+// the way Crossplane would when creating them.
+func composed(xr *unstructured.Unstructured, desired map[string]*fnv1.Resource) []ComposedResource {
+	out := make([]ComposedResource, 0, len(desired))
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		d := desired[name]
+		u := &unstructured.Unstructured{Object: d.GetResource().AsMap()}
+
+		meta := u.GetAnnotations()
+		if meta == nil {
+			meta = map[string]string{}
+		}
+		meta[AnnotationCompositionResourceName] = name
+		u.SetAnnotations(meta)
+
+		labels := u.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[LabelComposite] = xr.GetName()
+		u.SetLabels(labels)
+
+		if u.GetName() == "" && u.GetGenerateName() == "" {
+			u.SetGenerateName(xr.GetName() + "-")
+		}
+
+		cr := ComposedResource{Name: name, Resource: u.Object}
+		if d.GetReady() != fnv1.Ready_READY_UNSPECIFIED {
+			cr.Ready = strings.TrimPrefix(d.GetReady().String(), "READY_")
+		}
+		out = append(out, cr)
+	}
+	return out
+}
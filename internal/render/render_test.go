@@ -0,0 +1,274 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+// runnerFn runs functions by name.
+type runnerFn map[string]func(req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error)
+
+func (r runnerFn) RunFunction(_ context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	fn, ok := r[name]
+	if !ok {
+		return nil, errors.Errorf("no function %s", name)
+	}
+	return fn(req)
+}
+
+func mustStruct(m map[string]any) *structpb.Struct {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func compositeResource() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1alpha1",
+		"kind":       "XBucket",
+		"metadata":   map[string]any{"name": "bucket"},
+		"spec":       map[string]any{"region": "us-east-1"},
+	}}
+}
+
+func pipeline(steps ...map[string]any) *unstructured.Unstructured {
+	ps := make([]any, 0, len(steps))
+	for _, s := range steps {
+		ps = append(ps, s)
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "Composition",
+		"metadata":   map[string]any{"name": "xbuckets"},
+		"spec":       map[string]any{"mode": "Pipeline", "pipeline": ps},
+	}}
+}
+
+// compose desires a bucket in the XR's region and marks the XR ready.
+func compose(req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	region := req.GetObserved().GetComposite().GetResource().AsMap()["spec"].(map[string]any)["region"]
+	return &fnv1.RunFunctionResponse{
+		Desired: &fnv1.State{
+			Composite: &fnv1.Resource{Resource: mustStruct(map[string]any{"status": map[string]any{"region": region}})},
+			Resources: map[string]*fnv1.Resource{
+				"bucket": {Resource: mustStruct(map[string]any{
+					"apiVersion": "s3.aws.upbound.io/v1beta1",
+					"kind":       "Bucket",
+					"spec":       map[string]any{"forProvider": map[string]any{"region": region}},
+				})},
+			},
+		},
+		Context: mustStruct(map[string]any{"composed": true}),
+	}, nil
+}
+
+func TestRender(t *testing.T) {
+	type args struct {
+		runner  Runner
+		fetcher Fetcher
+		in      Inputs
+	}
+	type want struct {
+		out *Outputs
+		err bool
+	}
+
+	composedBucket := ComposedResource{
+		Name:  "bucket",
+		Ready: "TRUE",
+		Resource: map[string]any{
+			"apiVersion": "s3.aws.upbound.io/v1beta1",
+			"kind":       "Bucket",
+			"metadata": map[string]any{
+				"generateName": "bucket-",
+				"annotations":  map[string]any{AnnotationCompositionResourceName: "bucket"},
+				"labels":       map[string]any{LabelComposite: "bucket"},
+			},
+			"spec": map[string]any{"forProvider": map[string]any{"region": "us-east-1"}},
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Pipeline": {
+			reason: "Each step should see the desired state and context of the previous step.",
+			args: args{
+				runner: runnerFn{
+					"function-compose": compose,
+					"function-auto-ready": func(req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+						if !req.GetContext().AsMap()["composed"].(bool) {
+							return nil, errors.New("missing context")
+						}
+						d := req.GetDesired()
+						for _, r := range d.GetResources() {
+							r.Ready = fnv1.Ready_READY_TRUE
+						}
+						return &fnv1.RunFunctionResponse{
+							Desired: d,
+							Results: []*fnv1.Result{{Severity: fnv1.Severity_SEVERITY_NORMAL, Message: "all ready"}},
+						}, nil
+					},
+				},
+				in: Inputs{
+					CompositeResource: compositeResource(),
+					Composition: pipeline(
+						map[string]any{"step": "compose", "functionRef": map[string]any{"name": "function-compose"}},
+						map[string]any{
+							"step":        "ready",
+							"functionRef": map[string]any{"name": "function-auto-ready"},
+							"credentials": []any{map[string]any{"name": "creds"}},
+						},
+					),
+				},
+			},
+			want: want{
+				out: &Outputs{
+					CompositeResource: map[string]any{
+						"apiVersion": "example.org/v1alpha1",
+						"kind":       "XBucket",
+						"metadata":   map[string]any{"name": "bucket"},
+						"spec":       map[string]any{"region": "us-east-1"},
+						"status":     map[string]any{"region": "us-east-1"},
+					},
+					ComposedResources: []ComposedResource{composedBucket},
+					Results: []Result{
+						{Step: "ready", Severity: "Warning", Message: `credentials "creds" are not supplied when rendering`},
+						{Step: "ready", Severity: "Normal", Message: "all ready"},
+					},
+					Context: map[string]any{"composed": true},
+				},
+			},
+		},
+		"FatalResult": {
+			reason: "A fatal result should stop the pipeline and keep the desired state of earlier steps.",
+			args: args{
+				runner: runnerFn{
+					"function-compose": compose,
+					"function-fail": func(_ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+						reason := "InvalidInput"
+						return &fnv1.RunFunctionResponse{Results: []*fnv1.Result{{Severity: fnv1.Severity_SEVERITY_FATAL, Reason: &reason, Message: "boom"}}}, nil
+					},
+				},
+				in: Inputs{
+					CompositeResource: compositeResource(),
+					Composition: pipeline(
+						map[string]any{"step": "compose", "functionRef": map[string]any{"name": "function-compose"}},
+						map[string]any{"step": "fail", "functionRef": map[string]any{"name": "function-fail"}},
+						map[string]any{"step": "never", "functionRef": map[string]any{"name": "function-missing"}},
+					),
+				},
+			},
+			want: want{
+				out: &Outputs{
+					CompositeResource: map[string]any{
+						"apiVersion": "example.org/v1alpha1",
+						"kind":       "XBucket",
+						"metadata":   map[string]any{"name": "bucket"},
+						"spec":       map[string]any{"region": "us-east-1"},
+						"status":     map[string]any{"region": "us-east-1"},
+					},
+					ComposedResources: []ComposedResource{func() ComposedResource {
+						c := composedBucket
+						c.Ready = ""
+						return c
+					}()},
+					Results: []Result{{Step: "fail", Severity: "Fatal", Reason: "InvalidInput", Message: "boom"}},
+					Context: map[string]any{"composed": true},
+					Error:   `pipeline step "fail" returned a fatal result`,
+				},
+				err: true,
+			},
+		},
+		"ExtraResources": {
+			reason: "A function should be called again with the extra resources it requires.",
+			args: args{
+				runner: runnerFn{
+					"function-extra": func(req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+						rsp := &fnv1.RunFunctionResponse{Requirements: &fnv1.Requirements{ExtraResources: map[string]*fnv1.ResourceSelector{
+							"env": {ApiVersion: "apiextensions.crossplane.io/v1alpha1", Kind: "EnvironmentConfig", Match: &fnv1.ResourceSelector_MatchName{MatchName: "default"}},
+						}}}
+						if items := req.GetExtraResources()["env"].GetItems(); len(items) == 1 {
+							rsp.Results = []*fnv1.Result{{Severity: fnv1.Severity_SEVERITY_NORMAL, Message: "found " + items[0].GetResource().AsMap()["metadata"].(map[string]any)["name"].(string)}}
+						}
+						return rsp, nil
+					},
+				},
+				fetcher: NewObjectFetcher(objectfake.NewClient(nil, []*unstructured.Unstructured{{Object: map[string]any{
+					"apiVersion": "apiextensions.crossplane.io/v1alpha1",
+					"kind":       "EnvironmentConfig",
+					"metadata":   map[string]any{"name": "default"},
+				}}})),
+				in: Inputs{
+					CompositeResource: compositeResource(),
+					Composition:       pipeline(map[string]any{"step": "extra", "functionRef": map[string]any{"name": "function-extra"}}),
+				},
+			},
+			want: want{
+				out: &Outputs{
+					CompositeResource: compositeResource().Object,
+					ComposedResources: []ComposedResource{},
+					Results:           []Result{{Step: "extra", Severity: "Normal", Message: "found default"}},
+					Context:           map[string]any{},
+				},
+			},
+		},
+		"NotPipeline": {
+			reason: "Compositions without a pipeline cannot be rendered.",
+			args: args{
+				runner: runnerFn{},
+				in: Inputs{
+					CompositeResource: compositeResource(),
+					Composition:       &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"mode": "Resources"}}},
+				},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := New(tc.args.runner, WithFetcher(tc.args.fetcher))
+
+			got, err := r.Render(context.Background(), tc.args.in)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.out, got); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package render
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+// defaultRunTimeout is how long a single function call may take.
+const defaultRunTimeout = 30 * time.Second
+
+// A DevelopmentRunner runs functions that are already listening at a known
+// address, like the Development runtime of crossplane render. It never
+// starts containers. Connections are not authenticated, so only addresses
+// the operator allowed are dialed.
+type DevelopmentRunner struct {
+	addresses map[string]string
+	allowed   map[string]bool
+	timeout   time.Duration
+}
+
+// A RunnerOption modifies the underlying DevelopmentRunner.
+type RunnerOption func(*DevelopmentRunner)
+
+// WithRunTimeout overrides how long a single function call may take.
+func WithRunTimeout(d time.Duration) RunnerOption {
+	return func(r *DevelopmentRunner) {
+		r.timeout = d
+	}
+}
+
+// WithAllowedAddresses sets the addresses functions may be run at. No
+// address is allowed by default.
+func WithAllowedAddresses(addrs ...string) RunnerOption {
+	return func(r *DevelopmentRunner) {
+		for _, a := range addrs {
+			r.allowed[a] = true
+		}
+	}
+}
+
+// NewDevelopmentRunner returns a DevelopmentRunner that runs each named
+// function at the supplied address, for example localhost:9443.
+func NewDevelopmentRunner(addresses map[string]string, opts ...RunnerOption) *DevelopmentRunner {
+	r := &DevelopmentRunner{
+		addresses: addresses,
+		allowed:   map[string]bool{},
+		timeout:   defaultRunTimeout,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// RunFunction runs the named function.
+func (r *DevelopmentRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	addr, ok := r.addresses[name]
+	if !ok {
+		return nil, errors.Errorf("no address supplied for function %s", name)
+	}
+	if !r.allowed[addr] {
+		return nil, errors.Errorf("address %s of function %s is not allowed; the server's operator must allow it with --render-function-addresses", addr, name)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot connect to function %s at %s", name, addr)
+	}
+	defer conn.Close() //nolint:errcheck // Nothing useful to do with the error.
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	rsp, err := fnv1.NewFunctionRunnerServiceClient(conn).RunFunction(ctx, req)
+	return rsp, errors.Wrapf(err, "cannot run function %s at %s", name, addr)
+}
+
+// An ObjectFetcher fetches extra resources from the control plane. Extra
+// resources are cluster scoped. Functions may return what they fetch in
+// desired resources or results, so Secrets and namespaced kinds are never
+// fetched: they would let a function read Secret values, or list objects
+// across every namespace, with the server's permissions.
+type ObjectFetcher struct {
+	obj *object.Client
+}
+
+// NewObjectFetcher returns a Fetcher that reads extra resources using the
+// supplied client.
+func NewObjectFetcher(obj *object.Client) *ObjectFetcher {
+	return &ObjectFetcher{obj: obj}
+}
+
+// Fetch the resources matched by the supplied selector. A resource that is
+// selected by name but does not exist is not an error; the function decides
+// whether it is required.
+func (f *ObjectFetcher) Fetch(ctx context.Context, sel *fnv1.ResourceSelector) ([]*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(sel.GetApiVersion())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse apiVersion %q", sel.GetApiVersion())
+	}
+	gvk := gv.WithKind(sel.GetKind())
+
+	if gvk.GroupKind() == (schema.GroupKind{Kind: "Secret"}) {
+		return nil, errors.New("extra resources can't be Secrets")
+	}
+	m, err := f.obj.Mapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the API resource for %s", gvk)
+	}
+	if m.Scope.Name() != meta.RESTScopeNameRoot {
+		return nil, errors.Errorf("extra resources must be cluster scoped; %s is namespaced", gvk.Kind)
+	}
+
+	if name := sel.GetMatchName(); name != "" {
+		u, err := f.obj.Get(ctx, gvk, types.NamespacedName{Name: name})
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []*unstructured.Unstructured{u}, nil
+	}
+
+	ls := metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: sel.GetMatchLabels().GetLabels()})
+	items, err := f.obj.List(ctx, gvk, "", metav1.ListOptions{LabelSelector: ls})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*unstructured.Unstructured, 0, len(items))
+	for i := range items {
+		out = append(out, &items[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,116 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+func TestDevelopmentRunnerAllowed(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		opts   []RunnerOption
+		want   error
+	}{
+		"NothingAllowed": {
+			reason: "No address should be dialed unless the operator allowed it.",
+			want:   errors.New("address 169.254.169.254:80 of function function-a is not allowed; the server's operator must allow it with --render-function-addresses"),
+		},
+		"OtherAllowed": {
+			reason: "Only the allowed addresses should be dialed.",
+			opts:   []RunnerOption{WithAllowedAddresses("localhost:9443")},
+			want:   errors.New("address 169.254.169.254:80 of function function-a is not allowed; the server's operator must allow it with --render-function-addresses"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewDevelopmentRunner(map[string]string{"function-a": "169.254.169.254:80"}, tc.opts...)
+			_, err := r.RunFunction(context.Background(), "function-a", &fnv1.RunFunctionRequest{})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObjectFetcherFetch(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1alpha1",
+			"kind":       "EnvironmentConfig",
+			"metadata":   map[string]any{"name": "default"},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"namespace": "default", "name": "cm"},
+		}},
+	}
+	secret := objectfake.Kind{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, Namespaced: true}
+
+	cases := map[string]struct {
+		reason string
+		sel    *fnv1.ResourceSelector
+		want   []string
+		err    error
+	}{
+		"ClusterScoped": {
+			reason: "Cluster scoped extra resources should be fetched.",
+			sel:    &fnv1.ResourceSelector{ApiVersion: "apiextensions.crossplane.io/v1alpha1", Kind: "EnvironmentConfig", Match: &fnv1.ResourceSelector_MatchName{MatchName: "default"}},
+			want:   []string{"default"},
+		},
+		"Secret": {
+			reason: "Secrets should never be fetched, so that functions can't read Secret values.",
+			sel:    &fnv1.ResourceSelector{ApiVersion: "v1", Kind: "Secret", Match: &fnv1.ResourceSelector_MatchLabels{MatchLabels: &fnv1.MatchLabels{}}},
+			err:    errors.New("extra resources can't be Secrets"),
+		},
+		"Namespaced": {
+			reason: "Namespaced kinds should not be listed across every namespace.",
+			sel:    &fnv1.ResourceSelector{ApiVersion: "v1", Kind: "ConfigMap", Match: &fnv1.ResourceSelector_MatchLabels{MatchLabels: &fnv1.MatchLabels{}}},
+			err:    errors.New("extra resources must be cluster scoped; ConfigMap is namespaced"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := NewObjectFetcher(objectfake.NewClient([]objectfake.Kind{secret}, objs))
+			items, err := f.Fetch(context.Background(), tc.sel)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nFetch(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			var got []string
+			for _, u := range items {
+				got = append(got, u.GetName())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFetch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
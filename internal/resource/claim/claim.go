@@ -0,0 +1,341 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package claim provides tool helpers for describing Crossplane claims and the
+composite resources they are bound to.
+*/
+package claim
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/connection"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// Ways a claim's Composition is selected.
+	SelectionEnforced  = "Enforced"
+	SelectionSelector  = "Selector"
+	SelectionReference = "Reference"
+	SelectionDefault   = "Default"
+	SelectionNone      = "None"
+
+	// maxNamespaceEvents to return to the caller.
+	maxNamespaceEvents = 10
+)
+
+// Description describes a claim, the composite resource it is bound to and
+// its tenant namespace.
+type Description struct {
+	Claim object.Summary `json:"claim"`
+	Bound bool           `json:"bound"`
+	// UnboundReason explains why the claim isn't bound, if it isn't.
+	UnboundReason string     `json:"unboundReason,omitempty"`
+	Composite     *Composite `json:"composite,omitempty"`
+	Selection     Selection  `json:"selection"`
+	// Connection describes where the claim and its composite resource write
+	// their connection details. Secret values are never read into it.
+	Connection *connection.Details `json:"connection,omitempty"`
+	Events     []object.Event      `json:"events"`
+	// NamespaceEvents are the most recent events in the claim's namespace,
+	// most recent first.
+	NamespaceEvents []NamespaceEvent `json:"namespaceEvents"`
+	// Problems found describing the claim, if any.
+	Problems []string `json:"problems"`
+}
+
+// Composite is the composite resource a claim is bound to.
+type Composite struct {
+	object.Summary
+	Composition         string `json:"composition,omitempty"`
+	CompositionRevision string `json:"compositionRevision,omitempty"`
+	// ClaimRef is the claim the composite resource is bound to.
+	ClaimRef *object.Reference `json:"claimRef,omitempty"`
+}
+
+// Selection describes how a claim's Composition is selected.
+type Selection struct {
+	// Mode is Enforced, Selector, Reference, Default or None.
+	Mode string `json:"mode"`
+	// Composition is the selected Composition, if any.
+	Composition string            `json:"composition,omitempty"`
+	Selector    map[string]string `json:"selector,omitempty"`
+	// Candidates are the Compositions of the claim's composite resource kind
+	// that match the selector.
+	Candidates   []string `json:"candidates,omitempty"`
+	Default      string   `json:"default,omitempty"`
+	Enforced     string   `json:"enforced,omitempty"`
+	UpdatePolicy string   `json:"updatePolicy,omitempty"`
+}
+
+// NamespaceEvent is an event of a resource in a claim's namespace.
+type NamespaceEvent struct {
+	Resource object.Reference `json:"resource"`
+	Event    object.Event     `json:"event"`
+}
+
+// Claims provides methods for describing claims in the configured
+// controlplane.
+type Claims struct {
+	log  logging.Logger
+	obj  *object.Client
+	conn *connection.Connections
+}
+
+// Option modifies the underlying Claims.
+type Option func(*Claims)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(c *Claims) {
+		c.log = log
+	}
+}
+
+// New constructs a new Claims.
+func New(obj *object.Client, conn *connection.Connections, opts ...Option) *Claims {
+	c := &Claims{
+		log:  logging.NewNopLogger(),
+		obj:  obj,
+		conn: conn,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// Describe the supplied claim.
+func (c *Claims) Describe(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Description, error) {
+	cl, err := c.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	d := &Description{Claim: object.Summarize(cl), Problems: []string{}}
+	if d.Events, err = c.obj.Events(ctx, cl); err != nil {
+		return nil, err
+	}
+	if d.Composite, d.UnboundReason, err = c.composite(ctx, cl); err != nil {
+		return nil, err
+	}
+	d.Bound = d.UnboundReason == ""
+	if !d.Bound {
+		d.UnboundReason = unboundReason(d, d.UnboundReason)
+	}
+
+	d.Selection, err = c.selection(ctx, cl, d.Composite)
+	if err != nil {
+		d.Problems = append(d.Problems, err.Error())
+	}
+
+	if d.Connection, err = c.conn.Details(ctx, gvk, nn); err != nil {
+		d.Problems = append(d.Problems, err.Error())
+	}
+
+	if d.NamespaceEvents, err = c.namespaceEvents(ctx, cl.GetNamespace()); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// composite returns the composite resource the supplied claim is bound to.
+// If the claim isn't bound it also returns why.
+func (c *Claims) composite(ctx context.Context, cl *unstructured.Unstructured) (*Composite, string, error) {
+	var ref object.Reference
+	if err := fieldpath.Pave(cl.Object).GetValueInto("spec.resourceRef", &ref); err != nil || ref.Name == "" {
+		return nil, "claim does not reference a composite resource", nil
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	xr, err := c.obj.Get(ctx, gv.WithKind(ref.Kind), types.NamespacedName{Name: ref.Name})
+	if kerrors.IsNotFound(err) {
+		return nil, fmt.Sprintf("composite resource %s %s does not exist", ref.Kind, ref.Name), nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	x := &Composite{
+		Summary:             object.Summarize(xr),
+		Composition:         composition.XRString(xr, "compositionRef.name"),
+		CompositionRevision: composition.XRString(xr, "compositionRevisionRef.name"),
+	}
+	var claimRef object.Reference
+	if err := fieldpath.Pave(xr.Object).GetValueInto("spec.claimRef", &claimRef); err == nil && claimRef.Name != "" {
+		x.ClaimRef = &claimRef
+		if claimRef.Namespace != cl.GetNamespace() || claimRef.Name != cl.GetName() {
+			return x, fmt.Sprintf("composite resource %s %s is bound to claim %s/%s", ref.Kind, ref.Name, claimRef.Namespace, claimRef.Name), nil
+		}
+	}
+	return x, "", nil
+}
+
+// unboundReason explains why the described claim isn't bound. The claim's
+// unhealthy conditions and most recent warning event are more specific
+// than the supplied fallback.
+func unboundReason(d *Description, fallback string) string {
+	for _, cd := range d.Claim.Conditions {
+		if cd.Status != string(metav1.ConditionTrue) && cd.Message != "" {
+			return fmt.Sprintf("%s; %s: %s", fallback, cd.Type, cd.Message)
+		}
+	}
+	for _, e := range slices.Backward(d.Events) {
+		if e.Type == corev1.EventTypeWarning {
+			return fmt.Sprintf("%s; %s: %s", fallback, e.Reason, e.Message)
+		}
+	}
+	return fallback
+}
+
+// selection describes how the supplied claim's Composition is selected.
+func (c *Claims) selection(ctx context.Context, cl *unstructured.Unstructured, xr *Composite) (Selection, error) {
+	pv := fieldpath.Pave(cl.Object)
+	s := Selection{}
+	ref, _ := pv.GetString("spec.compositionRef.name")
+	s.UpdatePolicy, _ = pv.GetString("spec.compositionUpdatePolicy")
+	_ = pv.GetValueInto("spec.compositionSelector.matchLabels", &s.Selector)
+	if xr != nil {
+		ref = cmp.Or(xr.Composition, ref)
+	}
+
+	xrd, err := c.xrd(ctx, cl.GroupVersionKind())
+	if err != nil {
+		s.Mode, s.Composition = mode(s, ref), ref
+		return s, err
+	}
+	xpv := fieldpath.Pave(xrd.Object)
+	s.Default, _ = xpv.GetString("spec.defaultCompositionRef.name")
+	s.Enforced, _ = xpv.GetString("spec.enforcedCompositionRef.name")
+	s.Mode = mode(s, ref)
+
+	switch s.Mode {
+	case SelectionEnforced:
+		s.Composition = s.Enforced
+	case SelectionDefault:
+		s.Composition = cmp.Or(ref, s.Default)
+	default:
+		s.Composition = ref
+	}
+
+	if s.Mode != SelectionSelector {
+		return s, nil
+	}
+	group, _ := xpv.GetString("spec.group")
+	kind, _ := xpv.GetString("spec.names.kind")
+	comps, err := c.obj.List(ctx, composition.CompositionGVK(), "", metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(s.Selector).String(),
+	})
+	if err != nil {
+		return s, err
+	}
+	for _, comp := range comps {
+		var typeRef struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		_ = fieldpath.Pave(comp.Object).GetValueInto("spec.compositeTypeRef", &typeRef)
+		gv, err := schema.ParseGroupVersion(typeRef.APIVersion)
+		if err == nil && gv.Group == group && typeRef.Kind == kind {
+			s.Candidates = append(s.Candidates, comp.GetName())
+		}
+	}
+	if len(s.Candidates) == 0 {
+		return s, errors.Errorf("no Composition for %s matches the claim's compositionSelector", kind)
+	}
+	return s, nil
+}
+
+// mode returns how a Composition is selected. Crossplane writes the
+// selected Composition back to the claim's compositionRef, so a reference
+// to the default Composition is treated as a default selection.
+func mode(s Selection, ref string) string {
+	switch {
+	case s.Enforced != "":
+		return SelectionEnforced
+	case len(s.Selector) > 0:
+		return SelectionSelector
+	case ref != "" && ref != s.Default:
+		return SelectionReference
+	case s.Default != "":
+		return SelectionDefault
+	default:
+		return SelectionNone
+	}
+}
+
+// xrd returns the CompositeResourceDefinition that defines the supplied
+// claim kind.
+func (c *Claims) xrd(ctx context.Context, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	def, err := crd.ForKind(ctx, c.obj, gvk)
+	if err != nil {
+		return nil, err
+	}
+	name := crd.DefinedBy(def)
+	if name == "" {
+		return nil, errors.Errorf("%s is not defined by a CompositeResourceDefinition", gvk.Kind)
+	}
+	return c.obj.Get(ctx, composition.XRDGVK(), types.NamespacedName{Name: name})
+}
+
+// namespaceEvents returns the most recent events in the supplied namespace,
+// most recent first.
+func (c *Claims) namespaceEvents(ctx context.Context, ns string) ([]NamespaceEvent, error) {
+	l, err := c.obj.Clientset().CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	items := l.Items
+	slices.SortStableFunc(items, func(a, b corev1.Event) int {
+		return object.LastSeen(b).Compare(object.LastSeen(a))
+	})
+
+	out := []NamespaceEvent{}
+	for _, e := range items[:min(len(items), maxNamespaceEvents)] {
+		out = append(out, NamespaceEvent{
+			Resource: object.Reference{
+				APIVersion: e.InvolvedObject.APIVersion,
+				Kind:       e.InvolvedObject.Kind,
+				Namespace:  e.InvolvedObject.Namespace,
+				Name:       e.InvolvedObject.Name,
+			},
+			Event: object.ConvertEvent(e),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,186 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package claim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/connection"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var claimGVK = schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "PostgreSQLInstance"}
+
+func claim(spec map[string]any, conditions ...any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "PostgreSQLInstance",
+		"metadata":   map[string]any{"namespace": "team-a", "name": "my-db"},
+		"spec":       spec,
+		"status":     map[string]any{"conditions": conditions},
+	}}
+}
+
+func composite(claimNamespace, claimName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "XPostgreSQLInstance",
+		"metadata":   map[string]any{"name": "my-db-x7k2"},
+		"spec": map[string]any{
+			"claimRef":               map[string]any{"apiVersion": "example.org/v1", "kind": "PostgreSQLInstance", "namespace": claimNamespace, "name": claimName},
+			"compositionRef":         map[string]any{"name": "aws-postgres"},
+			"compositionRevisionRef": map[string]any{"name": "aws-postgres-abc"},
+		},
+	}}
+}
+
+func claimCRD() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "postgresqlinstances.example.org"},
+	}}
+	u.SetOwnerReferences([]metav1.OwnerReference{{Kind: "CompositeResourceDefinition", Name: "xpostgresqlinstances.example.org"}})
+	return u
+}
+
+func xrd() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "CompositeResourceDefinition",
+		"metadata":   map[string]any{"name": "xpostgresqlinstances.example.org"},
+		"spec": map[string]any{
+			"group":                 "example.org",
+			"names":                 map[string]any{"kind": "XPostgreSQLInstance"},
+			"defaultCompositionRef": map[string]any{"name": "gcp-postgres"},
+		},
+	}}
+}
+
+func comp(name, kind string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "Composition",
+		"metadata":   map[string]any{"name": name, "labels": map[string]any{"provider": "aws"}},
+		"spec":       map[string]any{"compositeTypeRef": map[string]any{"apiVersion": "example.org/v1", "kind": kind}},
+	}}
+}
+
+func TestDescribe(t *testing.T) {
+	selector := map[string]any{
+		"resourceRef":         map[string]any{"apiVersion": "example.org/v1", "kind": "XPostgreSQLInstance", "name": "my-db-x7k2"},
+		"compositionSelector": map[string]any{"matchLabels": map[string]any{"provider": "aws"}},
+		"compositionRef":      map[string]any{"name": "aws-postgres"},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "team-a", Name: "app.123"},
+		InvolvedObject: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-a", Name: "app"},
+		Reason:         "ScalingReplicaSet",
+	}
+	common := []*unstructured.Unstructured{claimCRD(), xrd(), comp("aws-postgres", "XPostgreSQLInstance"), comp("aws-bucket", "XBucket")}
+
+	cases := map[string]struct {
+		reason string
+		objs   []*unstructured.Unstructured
+		want   *Description
+	}{
+		"Bound": {
+			reason: "A bound claim should describe its composite resource and the Compositions its selector matches.",
+			objs:   append([]*unstructured.Unstructured{claim(selector), composite("team-a", "my-db")}, common...),
+			want: &Description{
+				Bound: true,
+				Composite: &Composite{
+					Summary:             object.Summary{APIVersion: "example.org/v1", Kind: "XPostgreSQLInstance", Name: "my-db-x7k2"},
+					Composition:         "aws-postgres",
+					CompositionRevision: "aws-postgres-abc",
+					ClaimRef:            &object.Reference{APIVersion: "example.org/v1", Kind: "PostgreSQLInstance", Namespace: "team-a", Name: "my-db"},
+				},
+				Selection: Selection{
+					Mode:        SelectionSelector,
+					Composition: "aws-postgres",
+					Selector:    map[string]string{"provider": "aws"},
+					Candidates:  []string{"aws-postgres"},
+					Default:     "gcp-postgres",
+				},
+				NamespaceEvents: []NamespaceEvent{{Resource: object.Reference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-a", Name: "app"}}},
+				Problems:        []string{},
+			},
+		},
+		"Unbound": {
+			reason: "A claim without a composite resource should explain why using its conditions and fall back to the default Composition.",
+			objs: append([]*unstructured.Unstructured{claim(map[string]any{},
+				map[string]any{"type": "Synced", "status": "False", "reason": "ReconcileError", "message": "cannot compose resources"},
+			)}, common...),
+			want: &Description{
+				UnboundReason:   "claim does not reference a composite resource; Synced: cannot compose resources",
+				Selection:       Selection{Mode: SelectionDefault, Composition: "gcp-postgres", Default: "gcp-postgres"},
+				NamespaceEvents: []NamespaceEvent{{Resource: object.Reference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-a", Name: "app"}}},
+				Problems:        []string{},
+			},
+		},
+		"BoundToAnotherClaim": {
+			reason: "A claim whose composite resource is bound to another claim should not be bound.",
+			objs:   append([]*unstructured.Unstructured{claim(selector), composite("team-b", "their-db")}, common...),
+			want: &Description{
+				UnboundReason: "composite resource XPostgreSQLInstance my-db-x7k2 is bound to claim team-b/their-db",
+				Composite: &Composite{
+					Summary:             object.Summary{APIVersion: "example.org/v1", Kind: "XPostgreSQLInstance", Name: "my-db-x7k2"},
+					Composition:         "aws-postgres",
+					CompositionRevision: "aws-postgres-abc",
+					ClaimRef:            &object.Reference{APIVersion: "example.org/v1", Kind: "PostgreSQLInstance", Namespace: "team-b", Name: "their-db"},
+				},
+				Selection: Selection{
+					Mode:        SelectionSelector,
+					Composition: "aws-postgres",
+					Selector:    map[string]string{"provider": "aws"},
+					Candidates:  []string{"aws-postgres"},
+					Default:     "gcp-postgres",
+				},
+				NamespaceEvents: []NamespaceEvent{{Resource: object.Reference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-a", Name: "app"}}},
+				Problems:        []string{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := objectfake.NewClient(nil, tc.objs, event)
+			c := New(obj, connection.New(obj))
+
+			got, err := c.Describe(context.Background(), claimGVK, types.NamespacedName{Namespace: "team-a", Name: "my-db"})
+			if err != nil {
+				t.Fatalf("\n%s\nDescribe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got,
+				cmpopts.IgnoreFields(Description{}, "Claim", "Events", "Connection"),
+				cmpopts.IgnoreFields(NamespaceEvent{}, "Event"),
+			); diff != "" {
+				t.Errorf("\n%s\nDescribe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package composition provides tool helpers for working with Crossplane
+Compositions and the composite resources that use them.
+*/
+package composition
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+const (
+	// Group of the Crossplane composition APIs.
+	Group = "apiextensions.crossplane.io"
+
+	// ModePipeline is the mode of Compositions that run a function
+	// pipeline.
+	ModePipeline = "Pipeline"
+	// ModeResources is the legacy mode of Compositions that patch and
+	// transform a list of resource templates.
+	ModeResources = "Resources"
+)
+
+// CompositionGVK is the GroupVersionKind of a Composition.
+func CompositionGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "Composition"}
+}
+
+// RevisionGVK is the GroupVersionKind of a CompositionRevision.
+func RevisionGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "CompositionRevision"}
+}
+
+// XRDGVK is the GroupVersionKind of a CompositeResourceDefinition.
+func XRDGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "CompositeResourceDefinition"}
+}
+
+// XRField returns the value of the supplied Crossplane machinery field of a
+// composite resource, such as compositionRef. Crossplane v2 nests these
+// fields under spec.crossplane while v1 keeps them directly under spec.
+func XRField(xr *unstructured.Unstructured, field string) (any, bool) {
+	pv := fieldpath.Pave(xr.Object)
+	for _, p := range []string{"spec.crossplane." + field, "spec." + field} {
+		if v, err := pv.GetValue(p); err == nil {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// XRString is like XRField for string fields.
+func XRString(xr *unstructured.Unstructured, field string) string {
+	v, _ := XRField(xr, field)
+	s, _ := v.(string)
+	return s
+}
+
+// Inspection describes how a composite resource is composed.
+type Inspection struct {
+	Composite           object.Summary `json:"composite"`
+	Composition         string         `json:"composition,omitempty"`
+	CompositionRevision string         `json:"compositionRevision,omitempty"`
+	UpdatePolicy        string         `json:"compositionUpdatePolicy,omitempty"`
+	Mode                string         `json:"mode,omitempty"`
+	// SelectionError explains why no Composition could be resolved.
+	SelectionError string `json:"selectionError,omitempty"`
+	Steps          []Step `json:"steps"`
+	// FailingStep is the first pipeline step that events or conditions of
+	// the composite resource attribute an error to.
+	FailingStep string         `json:"failingStep,omitempty"`
+	Events      []object.Event `json:"events"`
+}
+
+// Step is a step of a Composition's function pipeline.
+type Step struct {
+	Name            string         `json:"name"`
+	FunctionRef     string         `json:"functionRef"`
+	InputAPIVersion string         `json:"inputAPIVersion,omitempty"`
+	InputKind       string         `json:"inputKind,omitempty"`
+	Credentials     []Credential   `json:"credentials,omitempty"`
+	Function        FunctionHealth `json:"function"`
+	Errors          []string       `json:"errors,omitempty"`
+}
+
+// Credential is a credential supplied to a pipeline step.
+type Credential struct {
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// FunctionHealth is the health of the Function referenced by a step.
+type FunctionHealth struct {
+	Installed  string             `json:"installed,omitempty"`
+	Healthy    string             `json:"healthy,omitempty"`
+	Conditions []object.Condition `json:"conditions,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// pipelineStep is a step of a Composition's pipeline as stored in the API.
+type pipelineStep struct {
+	Step        string `json:"step"`
+	FunctionRef struct {
+		Name string `json:"name"`
+	} `json:"functionRef"`
+	Input *struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	} `json:"input"`
+	Credentials []struct {
+		Name      string `json:"name"`
+		Source    string `json:"source"`
+		SecretRef *struct {
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+		} `json:"secretRef"`
+	} `json:"credentials"`
+}
+
+// Compositions provides methods for deriving details for Compositions in
+// the configured controlplane.
+type Compositions struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Compositions.
+type Option func(*Compositions)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(c *Compositions) {
+		c.log = log
+	}
+}
+
+// New constructs a new Compositions.
+func New(obj *object.Client, opts ...Option) *Compositions {
+	c := &Compositions{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// GetComposite returns the composite resource of the supplied kind and name.
+func (c *Compositions) GetComposite(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*unstructured.Unstructured, error) {
+	return c.obj.Get(ctx, gvk, nn)
+}
+
+// Selected returns the Composition or CompositionRevision selected by the
+// supplied composite resource. The revision is preferred because it is what
+// Crossplane actually runs.
+func (c *Compositions) Selected(ctx context.Context, xr *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if rev := XRString(xr, "compositionRevisionRef.name"); rev != "" {
+		return c.obj.Get(ctx, RevisionGVK(), types.NamespacedName{Name: rev})
+	}
+	if comp := XRString(xr, "compositionRef.name"); comp != "" {
+		return c.obj.Get(ctx, CompositionGVK(), types.NamespacedName{Name: comp})
+	}
+	return nil, errors.Errorf("%s %s has not selected a Composition", xr.GetKind(), xr.GetName())
+}
+
+// Inspect the composition of the supplied composite resource.
+func (c *Compositions) Inspect(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Inspection, error) {
+	xr, err := c.GetComposite(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &Inspection{
+		Composite:           object.Summarize(xr),
+		Composition:         XRString(xr, "compositionRef.name"),
+		CompositionRevision: XRString(xr, "compositionRevisionRef.name"),
+		UpdatePolicy:        XRString(xr, "compositionUpdatePolicy"),
+		Steps:               []Step{},
+	}
+	if in.Events, err = c.obj.Events(ctx, xr); err != nil {
+		return nil, err
+	}
+
+	comp, err := c.Selected(ctx, xr)
+	if err != nil {
+		in.SelectionError = err.Error()
+		return in, nil
+	}
+
+	pv := fieldpath.Pave(comp.Object)
+	in.Mode, _ = pv.GetString("spec.mode")
+	if in.Mode == "" {
+		// Crossplane v2 only supports pipelines and doesn't set the mode.
+		in.Mode = ModeResources
+		if _, err := pv.GetValue("spec.pipeline"); err == nil {
+			in.Mode = ModePipeline
+		}
+	}
+	if in.Mode != ModePipeline {
+		return in, nil
+	}
+
+	var steps []pipelineStep
+	if err := pv.GetValueInto("spec.pipeline", &steps); err != nil {
+		return nil, errors.Wrapf(err, "cannot read the pipeline of %s %s", comp.GetKind(), comp.GetName())
+	}
+
+	// Messages that may attribute an error to a step.
+	msgs := make([]string, 0, len(in.Events)+len(in.Composite.Conditions))
+	for _, e := range in.Events {
+		msgs = append(msgs, e.Message)
+	}
+	for _, cd := range in.Composite.Conditions {
+		if cd.Status != "True" {
+			msgs = append(msgs, cd.Message)
+		}
+	}
+
+	for _, ps := range steps {
+		s := Step{
+			Name:        ps.Step,
+			FunctionRef: ps.FunctionRef.Name,
+			Function:    c.functionHealth(ctx, ps.FunctionRef.Name),
+			Errors:      stepErrors(ps.Step, msgs),
+		}
+		if ps.Input != nil {
+			s.InputAPIVersion, s.InputKind = ps.Input.APIVersion, ps.Input.Kind
+		}
+		for _, cr := range ps.Credentials {
+			cred := Credential{Name: cr.Name, Source: cr.Source}
+			if cr.SecretRef != nil {
+				cred.SecretRef = cr.SecretRef.Namespace + "/" + cr.SecretRef.Name
+			}
+			s.Credentials = append(s.Credentials, cred)
+		}
+		if len(s.Errors) > 0 && in.FailingStep == "" {
+			in.FailingStep = s.Name
+		}
+		in.Steps = append(in.Steps, s)
+	}
+
+	return in, nil
+}
+
+// functionHealth returns the health of the named Function.
+func (c *Compositions) functionHealth(ctx context.Context, name string) FunctionHealth {
+	fn, err := c.obj.Get(ctx, xpkg.GVK(xpkg.KindFunction), types.NamespacedName{Name: name})
+	if err != nil {
+		return FunctionHealth{Error: err.Error()}
+	}
+	h := FunctionHealth{Conditions: object.Conditions(fn)}
+	if cd, ok := object.FindCondition(h.Conditions, "Installed"); ok {
+		h.Installed = cd.Status
+	}
+	if cd, ok := object.FindCondition(h.Conditions, "Healthy"); ok {
+		h.Healthy = cd.Status
+	}
+	return h
+}
+
+// stepErrors returns the messages that refer to the named pipeline step.
+// Crossplane quotes the step name when reporting a step failure, for
+// example: cannot run Composition pipeline step "patch": ...
+func stepErrors(step string, msgs []string) []string {
+	quoted := fmt.Sprintf("%q", step)
+	var errs []string
+	for _, m := range msgs {
+		if strings.Contains(m, quoted) && !slices.Contains(errs, m) {
+			errs = append(errs, m)
+		}
+	}
+	return errs
+}
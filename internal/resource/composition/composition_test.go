@@ -0,0 +1,197 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package composition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+const stepFailure = `cannot compose resources: cannot run Composition pipeline step "render": function is unhealthy`
+
+func xr(spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1alpha1",
+		"kind":       "XDatabase",
+		"metadata":   map[string]any{"name": "db", "uid": "xr-uid"},
+		"spec":       spec,
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Synced", "status": "False", "reason": "ReconcileError", "message": stepFailure},
+			},
+		},
+	}}
+}
+
+func revision() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "CompositionRevision",
+		"metadata":   map[string]any{"name": "xdatabases-abc"},
+		"spec": map[string]any{
+			"mode": "Pipeline",
+			"pipeline": []any{
+				map[string]any{
+					"step":        "environment",
+					"functionRef": map[string]any{"name": "function-environment-configs"},
+				},
+				map[string]any{
+					"step":        "render",
+					"functionRef": map[string]any{"name": "function-go-templating"},
+					"input":       map[string]any{"apiVersion": "gotemplating.fn.crossplane.io/v1beta1", "kind": "GoTemplate"},
+					"credentials": []any{
+						map[string]any{"name": "creds", "source": "Secret", "secretRef": map[string]any{"namespace": "crossplane-system", "name": "creds"}},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func function(name, healthy string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "Function",
+		"metadata":   map[string]any{"name": name},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Installed", "status": "True"},
+				map[string]any{"type": "Healthy", "status": healthy},
+			},
+		},
+	}}
+}
+
+func TestInspect(t *testing.T) {
+	xrGVK := schema.GroupVersionKind{Group: "example.org", Version: "v1alpha1", Kind: "XDatabase"}
+
+	type args struct {
+		objs  []*unstructured.Unstructured
+		typed []runtime.Object
+	}
+	type want struct {
+		in  *Inspection
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotSelected": {
+			reason: "An XR that hasn't selected a Composition should explain why.",
+			args: args{
+				objs: []*unstructured.Unstructured{xr(map[string]any{})},
+			},
+			want: want{
+				in: &Inspection{
+					Composite: object.Summary{
+						APIVersion: "example.org/v1alpha1",
+						Kind:       "XDatabase",
+						Name:       "db",
+						Conditions: []object.Condition{{Type: "Synced", Status: "False", Reason: "ReconcileError", Message: stepFailure}},
+					},
+					SelectionError: "XDatabase db has not selected a Composition",
+					Steps:          []Step{},
+					Events:         []object.Event{},
+				},
+			},
+		},
+		"FailingStep": {
+			reason: "Errors mentioning a step should be attributed to it, alongside each step's function health.",
+			args: args{
+				objs: []*unstructured.Unstructured{
+					xr(map[string]any{"crossplane": map[string]any{
+						"compositionRef":         map[string]any{"name": "xdatabases"},
+						"compositionRevisionRef": map[string]any{"name": "xdatabases-abc"},
+					}}),
+					revision(),
+					function("function-go-templating", "False"),
+				},
+				typed: []runtime.Object{&corev1.Event{
+					ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "db.1"},
+					InvolvedObject: corev1.ObjectReference{Kind: "XDatabase", Name: "db", UID: "xr-uid"},
+					Reason:         "ComposeResources",
+					Message:        stepFailure,
+				}},
+			},
+			want: want{
+				in: &Inspection{
+					Composite: object.Summary{
+						APIVersion: "example.org/v1alpha1",
+						Kind:       "XDatabase",
+						Name:       "db",
+						Conditions: []object.Condition{{Type: "Synced", Status: "False", Reason: "ReconcileError", Message: stepFailure}},
+					},
+					Composition:         "xdatabases",
+					CompositionRevision: "xdatabases-abc",
+					Mode:                ModePipeline,
+					Steps: []Step{
+						{
+							Name:        "environment",
+							FunctionRef: "function-environment-configs",
+							Function:    FunctionHealth{Error: `failed to get Function function-environment-configs: functions.pkg.crossplane.io "function-environment-configs" not found`},
+						},
+						{
+							Name:            "render",
+							FunctionRef:     "function-go-templating",
+							InputAPIVersion: "gotemplating.fn.crossplane.io/v1beta1",
+							InputKind:       "GoTemplate",
+							Credentials:     []Credential{{Name: "creds", Source: "Secret", SecretRef: "crossplane-system/creds"}},
+							Function: FunctionHealth{
+								Installed:  "True",
+								Healthy:    "False",
+								Conditions: []object.Condition{{Type: "Installed", Status: "True"}, {Type: "Healthy", Status: "False"}},
+							},
+							Errors: []string{stepFailure},
+						},
+					},
+					FailingStep: "render",
+					Events:      []object.Event{{Reason: "ComposeResources", Message: stepFailure}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := New(objectfake.NewClient(nil, tc.args.objs, tc.args.typed...))
+
+			got, err := c.Inspect(context.Background(), xrGVK, types.NamespacedName{Name: "db"})
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nInspect(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.in, got, cmpopts.IgnoreFields(object.Event{}, "EventTime", "FirstTimestamp", "LastTimestamp")); diff != "" {
+				t.Errorf("\n%s\nInspect(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package composition
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// LabelCompositionName is the label CompositionRevisions use to refer to
+	// their Composition.
+	LabelCompositionName = "crossplane.io/composition-name"
+
+	// UpdatePolicyManual pins a composite resource to a CompositionRevision.
+	UpdatePolicyManual = "Manual"
+
+	// Kinds of the sections of a Composition a diff is grouped by.
+	sectionSpec     = "Spec"
+	sectionStep     = "Step"
+	sectionResource = "Resource"
+
+	// Kinds of changes.
+	changeAdded    = "Added"
+	changeRemoved  = "Removed"
+	changeModified = "Modified"
+)
+
+// Diff is a semantic diff between two revisions of a Composition.
+type Diff struct {
+	Composition string   `json:"composition"`
+	From        Revision `json:"from"`
+	To          Revision `json:"to"`
+	// Sections that differ, grouped by pipeline step, resource template or
+	// other top level spec field.
+	Sections []Section `json:"sections"`
+	// Composites are the composite resources that use the Composition.
+	Composites []Composite `json:"composites"`
+	// CompositesError explains why composite resources couldn't be listed.
+	CompositesError string `json:"compositesError,omitempty"`
+}
+
+// Revision identifies one side of a Diff.
+type Revision struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Revision int64  `json:"revision,omitempty"`
+}
+
+// Section is a part of a Composition that differs between revisions.
+type Section struct {
+	// Kind is Step, Resource or Spec.
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Change is Added, Removed or Modified.
+	Change string `json:"change"`
+	// Moved is set if a step or resource template changed position.
+	Moved   bool     `json:"moved,omitempty"`
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Change is a difference of a single field.
+type Change struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+	From   any    `json:"from,omitempty"`
+	To     any    `json:"to,omitempty"`
+}
+
+// Composite is a composite resource using a Composition.
+type Composite struct {
+	object.Reference
+	// Revision is the CompositionRevision the composite resource uses.
+	Revision     string `json:"revision,omitempty"`
+	UpdatePolicy string `json:"updatePolicy,omitempty"`
+	// Pinned is whether the composite resource stays on its revision rather
+	// than moving to the latest one.
+	Pinned bool `json:"pinned"`
+}
+
+// Diff the supplied CompositionRevisions. If to is empty the from revision
+// is diffed against its live Composition.
+func (c *Compositions) Diff(ctx context.Context, from, to string) (*Diff, error) {
+	f, err := c.obj.Get(ctx, RevisionGVK(), types.NamespacedName{Name: from})
+	if err != nil {
+		return nil, err
+	}
+	name := f.GetLabels()[LabelCompositionName]
+	if name == "" {
+		return nil, errors.Errorf("CompositionRevision %s has no %s label", from, LabelCompositionName)
+	}
+
+	var t *unstructured.Unstructured
+	if to == "" {
+		t, err = c.obj.Get(ctx, CompositionGVK(), types.NamespacedName{Name: name})
+	} else {
+		t, err = c.obj.Get(ctx, RevisionGVK(), types.NamespacedName{Name: to})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t.GetKind() == RevisionGVK().Kind && t.GetLabels()[LabelCompositionName] != name {
+		return nil, errors.Errorf("CompositionRevisions %s and %s belong to different Compositions", from, to)
+	}
+
+	d := &Diff{
+		Composition: name,
+		From:        revisionOf(f),
+		To:          revisionOf(t),
+		Sections:    DiffSpecs(spec(f), spec(t)),
+		Composites:  []Composite{},
+	}
+
+	var ref struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	_ = fieldpath.Pave(t.Object).GetValueInto("spec.compositeTypeRef", &ref)
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || ref.Kind == "" {
+		d.CompositesError = fmt.Sprintf("%s %s has no valid compositeTypeRef", t.GetKind(), t.GetName())
+		return d, nil
+	}
+	xrs, err := c.obj.List(ctx, gv.WithKind(ref.Kind), "", metav1.ListOptions{})
+	if err != nil {
+		d.CompositesError = err.Error()
+		return d, nil
+	}
+	for i := range xrs {
+		xr := &xrs[i]
+		if XRString(xr, "compositionRef.name") != name {
+			continue
+		}
+		policy := XRString(xr, "compositionUpdatePolicy")
+		d.Composites = append(d.Composites, Composite{
+			Reference:    object.ReferenceTo(xr),
+			Revision:     XRString(xr, "compositionRevisionRef.name"),
+			UpdatePolicy: policy,
+			Pinned:       policy == UpdatePolicyManual,
+		})
+	}
+	return d, nil
+}
+
+// revisionOf identifies the supplied Composition or CompositionRevision.
+func revisionOf(u *unstructured.Unstructured) Revision {
+	r := Revision{Kind: u.GetKind(), Name: u.GetName()}
+	r.Revision, _ = fieldpath.Pave(u.Object).GetInteger("spec.revision")
+	return r
+}
+
+// spec returns the spec of the supplied Composition or CompositionRevision,
+// minus the fields only revisions have.
+func spec(u *unstructured.Unstructured) map[string]any {
+	s, _ := u.Object["spec"].(map[string]any)
+	out := make(map[string]any, len(s))
+	for k, v := range s {
+		if k != "revision" {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// DiffSpecs returns the sections that differ between the supplied
+// Composition specs. Pipeline steps are matched by name and resource
+// templates by name, or by position if they are unnamed.
+func DiffSpecs(from, to map[string]any) []Section {
+	out := []Section{}
+	for _, k := range keys(from, to) {
+		switch k {
+		case "pipeline":
+			out = append(out, diffList(sectionStep, "step", from[k], to[k])...)
+		case "resources":
+			out = append(out, diffList(sectionResource, "name", from[k], to[k])...)
+		default:
+			changes := diffValues(k, from[k], to[k])
+			if len(changes) == 0 {
+				continue
+			}
+			change := changeModified
+			if len(changes) == 1 && changes[0].Path == k {
+				change = changes[0].Change
+			}
+			out = append(out, Section{Kind: sectionSpec, Name: k, Change: change, Changes: changes})
+		}
+	}
+	return out
+}
+
+// diffList diffs lists of pipeline steps or resource templates, matching
+// entries by the supplied key.
+func diffList(kind, key string, from, to any) []Section {
+	fl, _ := from.([]any)
+	tl, _ := to.([]any)
+	name := func(i int, v any) string {
+		m, _ := v.(map[string]any)
+		if s, ok := m[key].(string); ok && s != "" {
+			return s
+		}
+		return fmt.Sprintf("#%d", i)
+	}
+	index := func(l []any) (map[string]int, []string) {
+		idx := make(map[string]int, len(l))
+		order := make([]string, 0, len(l))
+		for i, v := range l {
+			n := name(i, v)
+			idx[n] = i
+			order = append(order, n)
+		}
+		return idx, order
+	}
+	fi, forder := index(fl)
+	ti, torder := index(tl)
+
+	out := []Section{}
+	for _, n := range forder {
+		if _, ok := ti[n]; !ok {
+			out = append(out, Section{Kind: kind, Name: n, Change: changeRemoved})
+		}
+	}
+	for _, n := range torder {
+		i, ok := fi[n]
+		if !ok {
+			out = append(out, Section{Kind: kind, Name: n, Change: changeAdded})
+			continue
+		}
+		s := Section{Kind: kind, Name: n, Change: changeModified, Moved: relativeIndex(forder, ti, n) != relativeIndex(torder, fi, n)}
+		s.Changes = diffValues("", fl[i], tl[ti[n]])
+		if len(s.Changes) > 0 || s.Moved {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// relativeIndex returns the position of the named entry among the entries
+// of the supplied order that are also in other. Positions are compared
+// relative to the shared entries so that additions and removals elsewhere
+// don't count as moves.
+func relativeIndex(order []string, other map[string]int, name string) int {
+	i := 0
+	for _, n := range order {
+		if n == name {
+			return i
+		}
+		if _, ok := other[n]; ok {
+			i++
+		}
+	}
+	return -1
+}
+
+// diffValues returns the changes between the supplied values at the
+// supplied path. Objects are diffed recursively and lists element by
+// element.
+func diffValues(path string, from, to any) []Change {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		return []Change{{Path: path, Change: changeAdded, To: to}}
+	case to == nil:
+		return []Change{{Path: path, Change: changeRemoved, From: from}}
+	}
+
+	fm, fok := from.(map[string]any)
+	tm, tok := to.(map[string]any)
+	if fok && tok {
+		var out []Change
+		for _, k := range keys(fm, tm) {
+			out = append(out, diffValues(join(path, k), fm[k], tm[k])...)
+		}
+		return out
+	}
+
+	fl, fok := from.([]any)
+	tl, tok := to.([]any)
+	if fok && tok {
+		var out []Change
+		for i := range max(len(fl), len(tl)) {
+			var f, t any
+			if i < len(fl) {
+				f = fl[i]
+			}
+			if i < len(tl) {
+				t = tl[i]
+			}
+			out = append(out, diffValues(fmt.Sprintf("%s[%d]", path, i), f, t)...)
+		}
+		return out
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return []Change{{Path: path, Change: changeModified, From: from, To: to}}
+}
+
+// join the supplied field path and field.
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// keys returns the sorted union of the keys of the supplied objects.
+func keys(a, b map[string]any) []string {
+	out := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		out = append(out, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			out = append(out, k)
+		}
+	}
+	slices.SortFunc(out, cmp.Compare[string])
+	return out
+}
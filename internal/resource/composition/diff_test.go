@@ -0,0 +1,160 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package composition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+func step(name, fn string, input map[string]any) any {
+	s := map[string]any{"step": name, "functionRef": map[string]any{"name": fn}}
+	if input != nil {
+		s["input"] = input
+	}
+	return s
+}
+
+func TestDiffSpecs(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		from   map[string]any
+		to     map[string]any
+		want   []Section
+	}{
+		"Pipeline": {
+			reason: "Pipeline steps should be matched by name and report added, removed, modified and moved steps.",
+			from: map[string]any{
+				"mode": "Pipeline",
+				"pipeline": []any{
+					step("environment", "function-environment-configs", nil),
+					step("render", "function-go-templating", map[string]any{"source": "Inline", "replicas": int64(1)}),
+					step("ready", "function-auto-ready", nil),
+				},
+			},
+			to: map[string]any{
+				"mode": "Pipeline",
+				"pipeline": []any{
+					step("render", "function-go-templating", map[string]any{"source": "Inline", "replicas": int64(3)}),
+					step("environment", "function-environment-configs", nil),
+					step("tag", "function-tag", nil),
+				},
+				"writeConnectionSecretsToNamespace": "crossplane-system",
+			},
+			want: []Section{
+				{Kind: sectionStep, Name: "ready", Change: changeRemoved},
+				{Kind: sectionStep, Name: "render", Change: changeModified, Moved: true, Changes: []Change{
+					{Path: "input.replicas", Change: changeModified, From: int64(1), To: int64(3)},
+				}},
+				{Kind: sectionStep, Name: "environment", Change: changeModified, Moved: true},
+				{Kind: sectionStep, Name: "tag", Change: changeAdded},
+				{Kind: sectionSpec, Name: "writeConnectionSecretsToNamespace", Change: changeAdded, Changes: []Change{
+					{Path: "writeConnectionSecretsToNamespace", Change: changeAdded, To: "crossplane-system"},
+				}},
+			},
+		},
+		"Resources": {
+			reason: "Resource templates should be matched by name and their patches diffed element by element.",
+			from: map[string]any{"resources": []any{map[string]any{
+				"name":    "bucket",
+				"patches": []any{map[string]any{"fromFieldPath": "spec.region"}},
+			}}},
+			to: map[string]any{"resources": []any{map[string]any{
+				"name":    "bucket",
+				"patches": []any{map[string]any{"fromFieldPath": "spec.location"}, map[string]any{"fromFieldPath": "spec.tags"}},
+			}}},
+			want: []Section{{Kind: sectionResource, Name: "bucket", Change: changeModified, Changes: []Change{
+				{Path: "patches[0].fromFieldPath", Change: changeModified, From: "spec.region", To: "spec.location"},
+				{Path: "patches[1]", Change: changeAdded, To: map[string]any{"fromFieldPath": "spec.tags"}},
+			}}},
+		},
+		"Unchanged": {
+			reason: "Identical specs should have no differing sections.",
+			from:   map[string]any{"pipeline": []any{step("render", "function-go-templating", nil)}},
+			to:     map[string]any{"pipeline": []any{step("render", "function-go-templating", nil)}},
+			want:   []Section{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffSpecs(tc.from, tc.to)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiffSpecs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	rev := func(name string, n int64, fn string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "CompositionRevision",
+			"metadata":   map[string]any{"name": name, "labels": map[string]any{LabelCompositionName: "xdatabases"}},
+			"spec": map[string]any{
+				"revision":         n,
+				"compositeTypeRef": map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "XDatabase"},
+				"pipeline":         []any{step("render", fn, nil)},
+			},
+		}}
+	}
+	composite := func(name, revision, policy string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.org/v1alpha1",
+			"kind":       "XDatabase",
+			"metadata":   map[string]any{"name": name},
+			"spec": map[string]any{
+				"compositionRef":          map[string]any{"name": "xdatabases"},
+				"compositionRevisionRef":  map[string]any{"name": revision},
+				"compositionUpdatePolicy": policy,
+			},
+		}}
+	}
+	objs := []*unstructured.Unstructured{
+		rev("xdatabases-1", 1, "function-go-templating"),
+		rev("xdatabases-2", 2, "function-kcl"),
+		composite("pinned", "xdatabases-1", "Manual"),
+		composite("latest", "xdatabases-2", "Automatic"),
+	}
+
+	got, err := New(objectfake.NewClient(nil, objs)).Diff(context.Background(), "xdatabases-1", "xdatabases-2")
+	if err != nil {
+		t.Fatalf("Diff(...): %v", err)
+	}
+	want := &Diff{
+		Composition: "xdatabases",
+		From:        Revision{Kind: "CompositionRevision", Name: "xdatabases-1", Revision: 1},
+		To:          Revision{Kind: "CompositionRevision", Name: "xdatabases-2", Revision: 2},
+		Sections: []Section{{Kind: sectionStep, Name: "render", Change: changeModified, Changes: []Change{
+			{Path: "functionRef.name", Change: changeModified, From: "function-go-templating", To: "function-kcl"},
+		}}},
+		Composites: []Composite{
+			{Reference: object.Reference{APIVersion: "example.org/v1alpha1", Kind: "XDatabase", Name: "latest"}, Revision: "xdatabases-2", UpdatePolicy: "Automatic"},
+			{Reference: object.Reference{APIVersion: "example.org/v1alpha1", Kind: "XDatabase", Name: "pinned"}, Revision: "xdatabases-1", UpdatePolicy: "Manual", Pinned: true},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nDiff(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,244 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package connection provides tool helpers for inspecting the connection
+details Crossplane claims, composite resources and managed resources write,
+without exposing their values.
+*/
+package connection
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+// secretRefPaths are the paths at which resources reference the Secret
+// their connection details are written to. Crossplane v2 composite
+// resources nest Crossplane's fields under spec.crossplane.
+var secretRefPaths = []string{
+	"spec.writeConnectionSecretToRef",
+	"spec.crossplane.writeConnectionSecretToRef",
+}
+
+// publishPath is the path at which resources reference the external secret
+// store their connection details are published to.
+const publishPath = "spec.publishConnectionDetailsTo"
+
+// Details describes where a resource's connection details are written.
+type Details struct {
+	Resource object.Summary `json:"resource"`
+	// Secrets the resource, and for a claim its composite resource, write
+	// connection details to.
+	Secrets []Secret `json:"secrets"`
+	// Published are the external secret stores connection details are
+	// published to. They are not checked.
+	Published []Publication `json:"published,omitempty"`
+	// Problems found with the connection details, if any.
+	Problems []string `json:"problems"`
+}
+
+// Secret describes a connection Secret. Its values are never read into the
+// result.
+type Secret struct {
+	// WrittenBy is the resource that writes the Secret.
+	WrittenBy object.Reference `json:"writtenBy"`
+	Path      string           `json:"path"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Exists    bool             `json:"exists"`
+	Type      string           `json:"type,omitempty"`
+	Keys      []Key            `json:"keys"`
+	// LastUpdated is when the Secret was last written.
+	LastUpdated string `json:"lastUpdated,omitempty"`
+	// Owner is the controller of the Secret, e.g. XPostgres/my-db-x7k2.
+	Owner string `json:"owner,omitempty"`
+	// Skipped explains why the Secret wasn't checked.
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Key is a key of a connection Secret.
+type Key struct {
+	Name string `json:"name"`
+	// Size of the key's value in bytes.
+	Size int `json:"size"`
+}
+
+// Publication is an external secret store connection details are
+// published to.
+type Publication struct {
+	WrittenBy object.Reference `json:"writtenBy"`
+	Name      string           `json:"name"`
+	ConfigRef string           `json:"configRef,omitempty"`
+}
+
+// Connections provides methods for inspecting connection details in the
+// configured controlplane.
+type Connections struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Connections.
+type Option func(*Connections)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(c *Connections) {
+		c.log = log
+	}
+}
+
+// New constructs a new Connections.
+func New(obj *object.Client, opts ...Option) *Connections {
+	c := &Connections{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// Details returns where the supplied resource writes its connection
+// details. For a claim the connection details of its composite resource are
+// also returned.
+func (c *Connections) Details(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Details, error) {
+	u, err := c.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	d := &Details{
+		Resource: object.Summarize(u),
+		Secrets:  []Secret{},
+		Problems: []string{},
+	}
+	c.add(ctx, d, u)
+
+	var xr object.Reference
+	if err := fieldpath.Pave(u.Object).GetValueInto("spec.resourceRef", &xr); err == nil && xr.Kind != "" {
+		gv, err := schema.ParseGroupVersion(xr.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		x, err := c.obj.Get(ctx, gv.WithKind(xr.Kind), types.NamespacedName{Name: xr.Name})
+		switch {
+		case err == nil:
+			c.add(ctx, d, x)
+		case kerrors.IsNotFound(err):
+			d.Problems = append(d.Problems, fmt.Sprintf("composite resource %s %s does not exist", xr.Kind, xr.Name))
+		default:
+			return nil, err
+		}
+	}
+
+	if len(d.Secrets) == 0 && len(d.Published) == 0 {
+		d.Problems = append(d.Problems, fmt.Sprintf("%s %s does not write its connection details to a Secret", u.GetKind(), u.GetName()))
+	}
+	return d, nil
+}
+
+// add the connection Secrets and publications of the supplied resource.
+func (c *Connections) add(ctx context.Context, d *Details, u *unstructured.Unstructured) {
+	pv := fieldpath.Pave(u.Object)
+	for _, p := range secretRefPaths {
+		var ref struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		}
+		if err := pv.GetValueInto(p, &ref); err != nil || ref.Name == "" {
+			continue
+		}
+		s := c.secret(ctx, object.ReferenceTo(u), p, types.NamespacedName{Namespace: cmp.Or(ref.Namespace, u.GetNamespace()), Name: ref.Name})
+		switch {
+		case s.Skipped != "":
+			// Not a problem with the Secret; the server can't read Secrets.
+		case s.Error != "":
+			d.Problems = append(d.Problems, fmt.Sprintf("cannot read Secret %s/%s: %s", s.Namespace, s.Name, s.Error))
+		case !s.Exists:
+			d.Problems = append(d.Problems, fmt.Sprintf("Secret %s/%s written by %s %s does not exist", s.Namespace, s.Name, u.GetKind(), u.GetName()))
+		case len(s.Keys) == 0:
+			d.Problems = append(d.Problems, fmt.Sprintf("Secret %s/%s written by %s %s has no keys", s.Namespace, s.Name, u.GetKind(), u.GetName()))
+		}
+		d.Secrets = append(d.Secrets, s)
+	}
+
+	name, _ := pv.GetString(publishPath + ".name")
+	if name == "" {
+		return
+	}
+	cfg, _ := pv.GetString(publishPath + ".configRef.name")
+	d.Published = append(d.Published, Publication{WrittenBy: object.ReferenceTo(u), Name: name, ConfigRef: cfg})
+}
+
+// secret describes the supplied connection Secret.
+func (c *Connections) secret(ctx context.Context, by object.Reference, path string, nn types.NamespacedName) Secret {
+	s := Secret{WrittenBy: by, Path: path, Namespace: nn.Namespace, Name: nn.Name, Keys: []Key{}}
+	sec, err := c.obj.Clientset().CoreV1().Secrets(nn.Namespace).Get(ctx, nn.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		return s
+	case object.SecretSkipped(err) != "":
+		s.Skipped = object.SecretSkipped(err)
+		return s
+	case err != nil:
+		s.Error = err.Error()
+		return s
+	}
+
+	s.Exists = true
+	s.Type = string(sec.Type)
+	s.LastUpdated = object.FormatTime(LastUpdated(sec))
+	if ref := metav1.GetControllerOf(sec); ref != nil {
+		s.Owner = ref.Kind + "/" + ref.Name
+	}
+	for k, v := range sec.Data {
+		s.Keys = append(s.Keys, Key{Name: k, Size: len(v)})
+	}
+	slices.SortFunc(s.Keys, func(a, b Key) int { return cmp.Compare(a.Name, b.Name) })
+	return s
+}
+
+// LastUpdated returns when the supplied Secret was last written, according
+// to its managed fields. It falls back to the Secret's creation time.
+func LastUpdated(s *corev1.Secret) time.Time {
+	t := s.GetCreationTimestamp().Time
+	for _, f := range s.GetManagedFields() {
+		if f.Time != nil && f.Time.After(t) {
+			t = f.Time.Time
+		}
+	}
+	return t
+}
@@ -0,0 +1,178 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var claimGVK = schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Postgres"}
+
+func claim() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "Postgres",
+		"metadata":   map[string]any{"namespace": "default", "name": "my-db"},
+		"spec": map[string]any{
+			"writeConnectionSecretToRef": map[string]any{"name": "my-db-conn"},
+			"resourceRef":                map[string]any{"apiVersion": "example.org/v1", "kind": "XPostgres", "name": "my-db-x7k2"},
+		},
+	}}
+}
+
+func xr() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "XPostgres",
+		"metadata":   map[string]any{"name": "my-db-x7k2"},
+		"spec": map[string]any{
+			"writeConnectionSecretToRef": map[string]any{"namespace": "crossplane-system", "name": "my-db-x7k2"},
+		},
+	}}
+}
+
+func TestDetails(t *testing.T) {
+	updated := metav1.NewTime(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	claimSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "my-db-conn",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Postgres", Name: "my-db", Controller: ptr.To(true)}},
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "crossplane", Time: &updated}},
+		},
+		Type: "connection.crossplane.io/v1alpha1",
+		Data: map[string][]byte{"username": []byte("admin"), "password": []byte("do-not-leak")},
+	}
+
+	cases := map[string]struct {
+		reason string
+		objs   []*unstructured.Unstructured
+		want   *Details
+	}{
+		"ClaimAndComposite": {
+			reason: "The Secrets of a claim and its composite resource should be described without their values.",
+			objs:   []*unstructured.Unstructured{claim(), xr()},
+			want: &Details{
+				Resource: object.Summary{APIVersion: "example.org/v1", Kind: "Postgres", Namespace: "default", Name: "my-db"},
+				Secrets: []Secret{
+					{
+						WrittenBy:   object.Reference{APIVersion: "example.org/v1", Kind: "Postgres", Namespace: "default", Name: "my-db"},
+						Path:        "spec.writeConnectionSecretToRef",
+						Namespace:   "default",
+						Name:        "my-db-conn",
+						Exists:      true,
+						Type:        "connection.crossplane.io/v1alpha1",
+						Keys:        []Key{{Name: "password", Size: 11}, {Name: "username", Size: 5}},
+						LastUpdated: "2025-01-02T00:00:00Z",
+						Owner:       "Postgres/my-db",
+					},
+					{
+						WrittenBy: object.Reference{APIVersion: "example.org/v1", Kind: "XPostgres", Name: "my-db-x7k2"},
+						Path:      "spec.writeConnectionSecretToRef",
+						Namespace: "crossplane-system",
+						Name:      "my-db-x7k2",
+						Keys:      []Key{},
+					},
+				},
+				Problems: []string{"Secret crossplane-system/my-db-x7k2 written by XPostgres my-db-x7k2 does not exist"},
+			},
+		},
+		"NoSecret": {
+			reason: "A resource that doesn't write connection details should be reported as a problem.",
+			objs: []*unstructured.Unstructured{{Object: map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "Postgres",
+				"metadata":   map[string]any{"namespace": "default", "name": "my-db"},
+			}}},
+			want: &Details{
+				Resource: object.Summary{APIVersion: "example.org/v1", Kind: "Postgres", Namespace: "default", Name: "my-db"},
+				Secrets:  []Secret{},
+				Problems: []string{"Postgres my-db does not write its connection details to a Secret"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := New(objectfake.NewClient(nil, tc.objs, claimSecret))
+			got, err := c.Details(context.Background(), claimGVK, types.NamespacedName{Namespace: "default", Name: "my-db"})
+			if err != nil {
+				t.Fatalf("\n%s\nDetails(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDetails(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDetailsForbidden(t *testing.T) {
+	cs := fake.NewClientset()
+	cs.PrependReactor("get", "secrets", func(a clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, a.(clienttesting.GetAction).GetName(), errors.New("no RBAC"))
+	})
+	c := New(objectfake.NewClientWithClientset(cs, nil, []*unstructured.Unstructured{claim(), xr()}))
+
+	got, err := c.Details(context.Background(), claimGVK, types.NamespacedName{Namespace: "default", Name: "my-db"})
+	if err != nil {
+		t.Fatalf("Details(...): %v", err)
+	}
+	skipped := object.SecretSkipped(kerrors.NewForbidden(schema.GroupResource{}, "", nil))
+	want := &Details{
+		Resource: object.Summary{APIVersion: "example.org/v1", Kind: "Postgres", Namespace: "default", Name: "my-db"},
+		Secrets: []Secret{
+			{
+				WrittenBy: object.Reference{APIVersion: "example.org/v1", Kind: "Postgres", Namespace: "default", Name: "my-db"},
+				Path:      "spec.writeConnectionSecretToRef",
+				Namespace: "default",
+				Name:      "my-db-conn",
+				Keys:      []Key{},
+				Skipped:   skipped,
+			},
+			{
+				WrittenBy: object.Reference{APIVersion: "example.org/v1", Kind: "XPostgres", Name: "my-db-x7k2"},
+				Path:      "spec.writeConnectionSecretToRef",
+				Namespace: "crossplane-system",
+				Name:      "my-db-x7k2",
+				Keys:      []Key{},
+				Skipped:   skipped,
+			},
+		},
+		Problems: []string{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nA Secret the server isn't allowed to get should be reported as skipped, not as a problem.\nDetails(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,443 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package core provides tool helpers for checking the health of Crossplane
+itself: its core and RBAC manager deployments, leader election and webhooks.
+*/
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+)
+
+const (
+	// Components of Crossplane, as labelled by its Helm chart.
+	ComponentCore        = "crossplane"
+	ComponentRBACManager = "crossplane-rbac-manager"
+
+	// LabelApp is the label Crossplane's Helm chart uses to identify its
+	// components.
+	LabelApp = "app"
+	// labelVersion is the label Crossplane's Helm chart uses to record the
+	// version of its components.
+	labelVersion = "app.kubernetes.io/version"
+
+	// flagPrefix prefixes the arguments that enable Crossplane features.
+	flagPrefix = "--enable-"
+
+	// namePrefix prefixes the names of Crossplane's leases and webhook
+	// configurations.
+	namePrefix = "crossplane"
+
+	// maxErrorLines of each pod to return to the caller.
+	maxErrorLines = 20
+)
+
+// Health is the health of Crossplane itself.
+type Health struct {
+	// Healthy is set if no problems were found.
+	Healthy     bool         `json:"healthy"`
+	Deployments []Deployment `json:"deployments"`
+	// Leases are Crossplane's leader election leases.
+	Leases   []Lease   `json:"leases"`
+	Webhooks []Webhook `json:"webhooks"`
+	// Problems found with Crossplane, if any.
+	Problems []string `json:"problems"`
+}
+
+// Deployment is a Deployment running a Crossplane component.
+type Deployment struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Component is crossplane or crossplane-rbac-manager.
+	Component         string        `json:"component"`
+	Version           string        `json:"version,omitempty"`
+	Image             string        `json:"image,omitempty"`
+	Replicas          int32         `json:"replicas"`
+	ReadyReplicas     int32         `json:"readyReplicas"`
+	UpdatedReplicas   int32         `json:"updatedReplicas"`
+	AvailableReplicas int32         `json:"availableReplicas"`
+	FeatureFlags      []FeatureFlag `json:"featureFlags"`
+	Pods              []Pod         `json:"pods"`
+}
+
+// FeatureFlag is a Crossplane feature enabled or disabled by an argument.
+type FeatureFlag struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Pod is a pod running a Crossplane component.
+type Pod struct {
+	Name     string `json:"name"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+	// ErrorLines are the most recent log lines that mention an error.
+	ErrorLines []string `json:"errorLines"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Lease is a leader election lease.
+type Lease struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Holder    string `json:"holder,omitempty"`
+	RenewTime string `json:"renewTime,omitempty"`
+	// Expired is set if the holder hasn't renewed the lease within its
+	// duration.
+	Expired bool `json:"expired"`
+}
+
+// Webhook is a webhook of one of Crossplane's webhook configurations.
+type Webhook struct {
+	// Configuration is the kind and name of the webhook configuration.
+	Configuration string `json:"configuration"`
+	Name          string `json:"name"`
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	// CABundle is set if the webhook has a CA bundle to verify the server's
+	// certificate.
+	CABundle         bool   `json:"caBundle"`
+	ServiceNamespace string `json:"serviceNamespace,omitempty"`
+	ServiceName      string `json:"serviceName,omitempty"`
+	ServiceExists    bool   `json:"serviceExists"`
+	ReadyEndpoints   int    `json:"readyEndpoints"`
+	Error            string `json:"error,omitempty"`
+}
+
+// Core provides methods for checking the health of Crossplane in the
+// configured controlplane.
+type Core struct {
+	log logging.Logger
+	cs  kubernetes.Interface
+	pod *pod.Pod
+
+	now func() time.Time
+}
+
+// Option modifies the underlying Core.
+type Option func(*Core)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(c *Core) {
+		c.log = log
+	}
+}
+
+// WithClock overrides the default clock.
+func WithClock(now func() time.Time) Option {
+	return func(c *Core) {
+		c.now = now
+	}
+}
+
+// New constructs a new Core. Logs are read using the supplied Pod.
+func New(cs kubernetes.Interface, p *pod.Pod, opts ...Option) *Core {
+	c := &Core{
+		log: logging.NewNopLogger(),
+		cs:  cs,
+		pod: p,
+		now: time.Now,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// Health returns the health of Crossplane.
+func (c *Core) Health(ctx context.Context) (*Health, error) {
+	h := &Health{Deployments: []Deployment{}, Leases: []Lease{}, Webhooks: []Webhook{}, Problems: []string{}}
+
+	req, err := labels.NewRequirement(LabelApp, selection.In, []string{ComponentCore, ComponentRBACManager})
+	if err != nil {
+		return nil, err
+	}
+	l, err := c.cs.AppsV1().Deployments("").List(ctx, metav1.ListOptions{LabelSelector: labels.NewSelector().Add(*req).String()})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list Crossplane deployments")
+	}
+	namespaces := []string{}
+	for _, d := range l.Items {
+		dep, err := c.deployment(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		h.Deployments = append(h.Deployments, *dep)
+		if !slices.Contains(namespaces, d.GetNamespace()) {
+			namespaces = append(namespaces, d.GetNamespace())
+		}
+	}
+	slices.SortFunc(h.Deployments, func(a, b Deployment) int { return cmp.Compare(a.Component, b.Component) })
+
+	for _, ns := range namespaces {
+		leases, err := c.leases(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		h.Leases = append(h.Leases, leases...)
+	}
+	if h.Webhooks, err = c.webhooks(ctx); err != nil {
+		return nil, err
+	}
+
+	h.Problems = problems(h)
+	h.Healthy = len(h.Problems) == 0
+	return h, nil
+}
+
+// deployment describes the supplied Crossplane deployment and its pods.
+func (c *Core) deployment(ctx context.Context, d appsv1.Deployment) (*Deployment, error) {
+	out := &Deployment{
+		Namespace:         d.GetNamespace(),
+		Name:              d.GetName(),
+		Component:         d.GetLabels()[LabelApp],
+		Version:           d.GetLabels()[labelVersion],
+		Replicas:          d.Status.Replicas,
+		ReadyReplicas:     d.Status.ReadyReplicas,
+		UpdatedReplicas:   d.Status.UpdatedReplicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+		FeatureFlags:      []FeatureFlag{},
+		Pods:              []Pod{},
+	}
+	if d.Spec.Replicas != nil {
+		out.Replicas = *d.Spec.Replicas
+	}
+	if cs := d.Spec.Template.Spec.Containers; len(cs) > 0 {
+		out.Image = cs[0].Image
+		out.FeatureFlags = FeatureFlags(cs[0].Args)
+	}
+	if out.Version == "" {
+		if i := strings.LastIndex(out.Image, ":"); i >= 0 && !strings.Contains(out.Image[i:], "/") {
+			out.Version = out.Image[i+1:]
+		}
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selector of Deployment %s/%s", d.GetNamespace(), d.GetName())
+	}
+	pods, err := c.cs.CoreV1().Pods(d.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods of Deployment %s/%s", d.GetNamespace(), d.GetName())
+	}
+	for _, p := range pods.Items {
+		out.Pods = append(out.Pods, c.podOf(ctx, p))
+	}
+	return out, nil
+}
+
+// podOf describes the supplied pod and the errors in its recent logs.
+func (c *Core) podOf(ctx context.Context, p corev1.Pod) Pod {
+	out := Pod{Name: p.GetName(), Phase: string(p.Status.Phase), ErrorLines: []string{}}
+	for _, cs := range p.Status.ContainerStatuses {
+		out.Restarts += cs.RestartCount
+	}
+	for _, cd := range p.Status.Conditions {
+		if cd.Type == corev1.PodReady {
+			out.Ready = cd.Status == corev1.ConditionTrue
+		}
+	}
+
+	logs, err := c.pod.GetLogs(ctx, types.NamespacedName{Namespace: p.GetNamespace(), Name: p.GetName()}, "")
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	for _, l := range strings.Split(strings.TrimSuffix(string(logs), "\n"), "\n") {
+		if strings.Contains(strings.ToLower(l), "error") {
+			out.ErrorLines = append(out.ErrorLines, l)
+		}
+	}
+	out.ErrorLines = out.ErrorLines[max(len(out.ErrorLines)-maxErrorLines, 0):]
+	return out
+}
+
+// FeatureFlags returns the features enabled or disabled by the supplied
+// arguments, such as --enable-usages or --enable-realtime-compositions=false.
+func FeatureFlags(args []string) []FeatureFlag {
+	out := []FeatureFlag{}
+	for _, a := range args {
+		name, ok := strings.CutPrefix(a, flagPrefix)
+		if !ok {
+			continue
+		}
+		name, value, _ := strings.Cut(name, "=")
+		out = append(out, FeatureFlag{Name: name, Enabled: value == "" || value == "true"})
+	}
+	return out
+}
+
+// leases returns the Crossplane leader election leases in the supplied
+// namespace.
+func (c *Core) leases(ctx context.Context, ns string) ([]Lease, error) {
+	l, err := c.cs.CoordinationV1().Leases(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list leases in namespace %s", ns)
+	}
+	out := []Lease{}
+	for _, le := range l.Items {
+		if !strings.HasPrefix(le.GetName(), namePrefix) {
+			continue
+		}
+		o := Lease{Namespace: le.GetNamespace(), Name: le.GetName()}
+		if le.Spec.HolderIdentity != nil {
+			o.Holder = *le.Spec.HolderIdentity
+		}
+		if rt := le.Spec.RenewTime; rt != nil && le.Spec.LeaseDurationSeconds != nil {
+			o.RenewTime = rt.UTC().Format(time.RFC3339)
+			o.Expired = c.now().After(rt.Add(time.Duration(*le.Spec.LeaseDurationSeconds) * time.Second))
+		}
+		out = append(out, o)
+	}
+	return out, nil
+}
+
+// webhooks returns the webhooks of Crossplane's validating and mutating
+// webhook configurations.
+func (c *Core) webhooks(ctx context.Context) ([]Webhook, error) {
+	out := []Webhook{}
+	vl, err := c.cs.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list validating webhook configurations")
+	}
+	for _, cfg := range vl.Items {
+		if !strings.HasPrefix(cfg.GetName(), namePrefix) {
+			continue
+		}
+		for _, w := range cfg.Webhooks {
+			out = append(out, c.webhook(ctx, "ValidatingWebhookConfiguration/"+cfg.GetName(), w.Name, w.ClientConfig, w.FailurePolicy))
+		}
+	}
+
+	ml, err := c.cs.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list mutating webhook configurations")
+	}
+	for _, cfg := range ml.Items {
+		if !strings.HasPrefix(cfg.GetName(), namePrefix) {
+			continue
+		}
+		for _, w := range cfg.Webhooks {
+			out = append(out, c.webhook(ctx, "MutatingWebhookConfiguration/"+cfg.GetName(), w.Name, w.ClientConfig, w.FailurePolicy))
+		}
+	}
+	return out, nil
+}
+
+// webhook describes the supplied webhook and checks the Service it calls.
+func (c *Core) webhook(ctx context.Context, cfg, name string, cc admissionv1.WebhookClientConfig, fp *admissionv1.FailurePolicyType) Webhook {
+	w := Webhook{Configuration: cfg, Name: name, CABundle: len(cc.CABundle) > 0}
+	if fp != nil {
+		w.FailurePolicy = string(*fp)
+	}
+	if cc.Service == nil {
+		return w
+	}
+	w.ServiceNamespace, w.ServiceName = cc.Service.Namespace, cc.Service.Name
+
+	_, err := c.cs.CoreV1().Services(w.ServiceNamespace).Get(ctx, w.ServiceName, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		return w
+	case err != nil:
+		w.Error = err.Error()
+		return w
+	}
+	w.ServiceExists = true
+
+	eps, err := c.cs.DiscoveryV1().EndpointSlices(w.ServiceNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: w.ServiceName}).String(),
+	})
+	if err != nil {
+		w.Error = err.Error()
+		return w
+	}
+	for _, es := range eps.Items {
+		for _, e := range es.Endpoints {
+			if e.Conditions.Ready == nil || *e.Conditions.Ready {
+				w.ReadyEndpoints++
+			}
+		}
+	}
+	return w
+}
+
+// problems summarizes the problems with the supplied health.
+func problems(h *Health) []string {
+	out := []string{}
+	for _, comp := range []string{ComponentCore, ComponentRBACManager} {
+		if !slices.ContainsFunc(h.Deployments, func(d Deployment) bool { return d.Component == comp }) {
+			out = append(out, fmt.Sprintf("no %s Deployment found", comp))
+		}
+	}
+	for _, d := range h.Deployments {
+		if d.ReadyReplicas < d.Replicas {
+			out = append(out, fmt.Sprintf("Deployment %s/%s has %d of %d replicas ready", d.Namespace, d.Name, d.ReadyReplicas, d.Replicas))
+		}
+		for _, p := range d.Pods {
+			if len(p.ErrorLines) > 0 {
+				out = append(out, fmt.Sprintf("pod %s/%s logged %d recent error lines", d.Namespace, p.Name, len(p.ErrorLines)))
+			}
+		}
+	}
+	for _, l := range h.Leases {
+		switch {
+		case l.Holder == "":
+			out = append(out, fmt.Sprintf("lease %s/%s has no holder", l.Namespace, l.Name))
+		case l.Expired:
+			out = append(out, fmt.Sprintf("lease %s/%s held by %s expired; it was last renewed at %s", l.Namespace, l.Name, l.Holder, l.RenewTime))
+		}
+	}
+	for _, w := range h.Webhooks {
+		switch {
+		case w.Error != "":
+			out = append(out, fmt.Sprintf("cannot check webhook %s of %s: %s", w.Name, w.Configuration, w.Error))
+		case !w.CABundle:
+			out = append(out, fmt.Sprintf("webhook %s of %s has no CA bundle", w.Name, w.Configuration))
+		case w.ServiceName != "" && !w.ServiceExists:
+			out = append(out, fmt.Sprintf("Service %s/%s of webhook %s does not exist", w.ServiceNamespace, w.ServiceName, w.Name))
+		case w.ServiceName != "" && w.ReadyEndpoints == 0:
+			out = append(out, fmt.Sprintf("Service %s/%s of webhook %s has no ready endpoints", w.ServiceNamespace, w.ServiceName, w.Name))
+		}
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+)
+
+func deployment(component string, args ...string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "crossplane-system",
+			Name:      component,
+			Labels:    map[string]string{LabelApp: component},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{LabelApp: component}},
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  component,
+				Image: "xpkg.crossplane.io/crossplane/crossplane:v1.20.0",
+				Args:  args,
+			}}}},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func TestHealth(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	crossplanePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "crossplane-abc", Labels: map[string]string{LabelApp: ComponentCore}},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 2}},
+		},
+	}
+	lease := func(name string, renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To("crossplane-abc"),
+				LeaseDurationSeconds: ptr.To[int32](60),
+				RenewTime:            &metav1.MicroTime{Time: renewed},
+			},
+		}
+	}
+	webhooks := &admissionv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "crossplane"},
+		Webhooks: []admissionv1.ValidatingWebhook{{
+			Name:          "compositeresourcedefinitions.apiextensions.crossplane.io",
+			FailurePolicy: ptr.To(admissionv1.Fail),
+			ClientConfig: admissionv1.WebhookClientConfig{
+				CABundle: []byte("ca"),
+				Service:  &admissionv1.ServiceReference{Namespace: "crossplane-system", Name: "crossplane-webhooks"},
+			},
+		}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		objs   []runtime.Object
+		want   *Health
+	}{
+		"Unhealthy": {
+			reason: "A missing RBAC manager, expired lease and webhook without a Service should be reported.",
+			objs: []runtime.Object{
+				deployment(ComponentCore, "core", "start", "--enable-usages", "--enable-realtime-compositions=false"),
+				crossplanePod,
+				lease("crossplane-leader-election-core", now.Add(-time.Minute)),
+				lease("crossplane-leader-election-rbac", now.Add(-time.Hour)),
+				lease("other", now.Add(-time.Hour)),
+				webhooks,
+			},
+			want: &Health{
+				Deployments: []Deployment{{
+					Namespace: "crossplane-system", Name: ComponentCore, Component: ComponentCore,
+					Version: "v1.20.0", Image: "xpkg.crossplane.io/crossplane/crossplane:v1.20.0",
+					Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1,
+					FeatureFlags: []FeatureFlag{{Name: "usages", Enabled: true}, {Name: "realtime-compositions"}},
+					Pods:         []Pod{{Name: "crossplane-abc", Phase: "Running", Ready: true, Restarts: 2, ErrorLines: []string{}}},
+				}},
+				Leases: []Lease{
+					{Namespace: "crossplane-system", Name: "crossplane-leader-election-core", Holder: "crossplane-abc", RenewTime: "2025-01-01T11:59:00Z"},
+					{Namespace: "crossplane-system", Name: "crossplane-leader-election-rbac", Holder: "crossplane-abc", RenewTime: "2025-01-01T11:00:00Z", Expired: true},
+				},
+				Webhooks: []Webhook{{
+					Configuration:    "ValidatingWebhookConfiguration/crossplane",
+					Name:             "compositeresourcedefinitions.apiextensions.crossplane.io",
+					FailurePolicy:    "Fail",
+					CABundle:         true,
+					ServiceNamespace: "crossplane-system",
+					ServiceName:      "crossplane-webhooks",
+				}},
+				Problems: []string{
+					"no crossplane-rbac-manager Deployment found",
+					"lease crossplane-system/crossplane-leader-election-rbac held by crossplane-abc expired; it was last renewed at 2025-01-01T11:00:00Z",
+					"Service crossplane-system/crossplane-webhooks of webhook compositeresourcedefinitions.apiextensions.crossplane.io does not exist",
+				},
+			},
+		},
+		"NotInstalled": {
+			reason: "A controlplane without Crossplane should report its missing components.",
+			want: &Health{
+				Deployments: []Deployment{},
+				Leases:      []Lease{},
+				Webhooks:    []Webhook{},
+				Problems:    []string{"no crossplane Deployment found", "no crossplane-rbac-manager Deployment found"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cs := fake.NewClientset(tc.objs...)
+			c := New(cs, pod.New(cs), WithClock(func() time.Time { return now }))
+
+			got, err := c.Health(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\nHealth(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nHealth(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package crd provides tool helpers for working with CustomResourceDefinitions,
+including those Crossplane generates for CompositeResourceDefinitions.
+*/
+package crd
+
+import (
+	"context"
+	"slices"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+// KindXRD is the kind of a Crossplane CompositeResourceDefinition.
+const KindXRD = "CompositeResourceDefinition"
+
+// GVK is the GroupVersionKind of a CustomResourceDefinition.
+func GVK() schema.GroupVersionKind {
+	return extv1.SchemeGroupVersion.WithKind("CustomResourceDefinition")
+}
+
+// Get the named CustomResourceDefinition.
+func Get(ctx context.Context, obj *object.Client, name string) (*extv1.CustomResourceDefinition, error) {
+	u, err := obj.Get(ctx, GVK(), types.NamespacedName{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return Convert(u)
+}
+
+// ForKind returns the CustomResourceDefinition that serves the supplied kind.
+func ForKind(ctx context.Context, obj *object.Client, gvk schema.GroupVersionKind) (*extv1.CustomResourceDefinition, error) {
+	m, err := obj.Mapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not served by the controlplane", gvk)
+	}
+	c, err := Get(ctx, obj, m.Resource.GroupResource().String())
+	if kerrors.IsNotFound(err) {
+		return nil, errors.Errorf("%s is not defined by a CustomResourceDefinition", gvk.Kind)
+	}
+	return c, err
+}
+
+// List all CustomResourceDefinitions.
+func List(ctx context.Context, obj *object.Client) ([]*extv1.CustomResourceDefinition, error) {
+	items, err := obj.List(ctx, GVK(), "", metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*extv1.CustomResourceDefinition, 0, len(items))
+	for i := range items {
+		c, err := Convert(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// Convert an unstructured CustomResourceDefinition.
+func Convert(u *unstructured.Unstructured) (*extv1.CustomResourceDefinition, error) {
+	c := &extv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, c); err != nil {
+		return nil, errors.Wrapf(err, "cannot convert CustomResourceDefinition %s", u.GetName())
+	}
+	return c, nil
+}
+
+// Version returns the named version of the CustomResourceDefinition, or its
+// storage version if the name is empty. The version must be served.
+func Version(c *extv1.CustomResourceDefinition, name string) (*extv1.CustomResourceDefinitionVersion, error) {
+	i := slices.IndexFunc(c.Spec.Versions, func(v extv1.CustomResourceDefinitionVersion) bool {
+		if name == "" {
+			return v.Storage
+		}
+		return v.Name == name
+	})
+	if i < 0 || !c.Spec.Versions[i].Served {
+		return nil, errors.Errorf("version %s is not served by CustomResourceDefinition %s", name, c.GetName())
+	}
+	return &c.Spec.Versions[i], nil
+}
+
+// DefinedBy returns the name of the CompositeResourceDefinition that owns the
+// supplied CustomResourceDefinition, if any.
+func DefinedBy(c *extv1.CustomResourceDefinition) string {
+	for _, ref := range c.GetOwnerReferences() {
+		if ref.Kind == KindXRD {
+			return ref.Name
+		}
+	}
+	return ""
+}
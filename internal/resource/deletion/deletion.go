@@ -0,0 +1,333 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package deletion provides tool helpers for explaining why the deletion of a
+Crossplane resource is blocked.
+*/
+package deletion
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	xpmeta "github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+// finalizers describes the finalizers commonly found on Crossplane
+// resources.
+var finalizers = map[string]string{
+	"finalizer.managedresource.crossplane.io": "The provider removes it after deleting the external resource, or immediately if the deletion policy is Orphan.",
+	"composite.apiextensions.crossplane.io":   "Crossplane removes it after deleting the composite resource's composed resources.",
+	"finalizer.apiextensions.crossplane.io":   "Crossplane removes it after deleting the claim's composite resource.",
+	"foregroundDeletion":                      "Kubernetes removes it after deleting every dependent that blocks owner deletion.",
+}
+
+// usageKinds are the kinds of the Crossplane Usages that can block the
+// deletion of a resource.
+var usageKinds = []schema.GroupKind{
+	{Group: "protection.crossplane.io", Kind: "Usage"},
+	{Group: "protection.crossplane.io", Kind: "ClusterUsage"},
+	{Group: "apiextensions.crossplane.io", Kind: "Usage"},
+}
+
+// UsageKinds returns the kinds of the Crossplane Usages that can block the
+// deletion of a resource.
+func UsageKinds() []schema.GroupKind {
+	return slices.Clone(usageKinds)
+}
+
+// Explanation explains why the deletion of a resource is blocked.
+type Explanation struct {
+	Resource          object.Summary `json:"resource"`
+	Deleting          bool           `json:"deleting"`
+	DeletionTimestamp string         `json:"deletionTimestamp,omitempty"`
+	Finalizers        []Finalizer    `json:"finalizers"`
+	// Usages that prevent the resource from being deleted.
+	Usages []Usage `json:"usages"`
+	// Dependents are the composed resources, or for a claim the composite
+	// resource, that still exist.
+	Dependents         []Dependent `json:"dependents"`
+	DeletionPolicy     string      `json:"deletionPolicy,omitempty"`
+	ManagementPolicies []string    `json:"managementPolicies,omitempty"`
+	Paused             bool        `json:"paused"`
+	// Errors are the messages of the resource's conditions that aren't
+	// True, which include the provider's deletion errors.
+	Errors []string `json:"errors"`
+	// Reasons summarizes why the deletion is blocked.
+	Reasons []string       `json:"reasons"`
+	Events  []object.Event `json:"events"`
+}
+
+// Finalizer is a finalizer of a resource.
+type Finalizer struct {
+	Name string `json:"name"`
+	// Meaning explains what removes the finalizer, for well known
+	// finalizers.
+	Meaning string `json:"meaning,omitempty"`
+}
+
+// Usage is a Crossplane Usage of a resource.
+type Usage struct {
+	Kind      string            `json:"kind"`
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	By        *object.Reference `json:"by,omitempty"`
+	Reason    string            `json:"reason,omitempty"`
+}
+
+// Dependent is a resource that must be deleted before the explained
+// resource.
+type Dependent struct {
+	object.Reference
+	Deleting   bool     `json:"deleting"`
+	Finalizers []string `json:"finalizers,omitempty"`
+}
+
+// Explainer explains why the deletion of resources in the configured
+// controlplane is blocked.
+type Explainer struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Explainer.
+type Option func(*Explainer)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(e *Explainer) {
+		e.log = log
+	}
+}
+
+// New constructs a new Explainer.
+func New(obj *object.Client, opts ...Option) *Explainer {
+	e := &Explainer{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(e)
+	}
+
+	return e
+}
+
+// Explain why the deletion of the supplied resource is blocked.
+func (e *Explainer) Explain(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Explanation, error) {
+	u, err := e.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	pv := fieldpath.Pave(u.Object)
+
+	ex := &Explanation{
+		Resource:   object.Summarize(u),
+		Finalizers: []Finalizer{},
+		Dependents: []Dependent{},
+		Paused:     xpmeta.IsPaused(u),
+		Errors:     []string{},
+		Reasons:    []string{},
+	}
+	if ts := u.GetDeletionTimestamp(); ts != nil {
+		ex.Deleting = true
+		ex.DeletionTimestamp = object.FormatTime(ts.Time)
+	}
+	for _, f := range u.GetFinalizers() {
+		ex.Finalizers = append(ex.Finalizers, Finalizer{Name: f, Meaning: finalizers[f]})
+	}
+	for _, c := range ex.Resource.Conditions {
+		if c.Status != string(metav1.ConditionTrue) && c.Message != "" {
+			ex.Errors = append(ex.Errors, fmt.Sprintf("%s: %s", c.Type, c.Message))
+		}
+	}
+	ex.DeletionPolicy, _ = pv.GetString("spec.deletionPolicy")
+	ex.ManagementPolicies, _ = pv.GetStringArray("spec.managementPolicies")
+
+	if ex.Usages, err = e.usages(ctx, u); err != nil {
+		return nil, err
+	}
+	if ex.Dependents, err = e.dependents(ctx, u); err != nil {
+		return nil, err
+	}
+	if ex.Events, err = e.obj.Events(ctx, u); err != nil {
+		return nil, err
+	}
+
+	ex.Reasons = reasons(ex)
+	return ex, nil
+}
+
+// usages returns the Usages that prevent the supplied resource from being
+// deleted. Usage kinds that aren't served are skipped.
+func (e *Explainer) usages(ctx context.Context, u *unstructured.Unstructured) ([]Usage, error) {
+	out := []Usage{}
+	for _, gk := range usageKinds {
+		m, err := e.obj.Mapper().RESTMapping(gk)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot find %s kind", gk)
+		}
+		l, err := e.obj.List(ctx, m.GroupVersionKind, "", metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l {
+			if us, ok := usageOf(&i, u); ok {
+				out = append(out, us)
+			}
+		}
+	}
+	return out, nil
+}
+
+// usageOf returns the supplied Usage if it protects the supplied resource.
+func usageOf(usage, u *unstructured.Unstructured) (Usage, bool) {
+	refs := ParseUsage(usage)
+	of := refs.Of
+	if schema.FromAPIVersionAndKind(of.APIVersion, of.Kind).GroupKind() != u.GroupVersionKind().GroupKind() ||
+		of.Namespace != u.GetNamespace() || of.Name != u.GetName() {
+		return Usage{}, false
+	}
+	return Usage{Kind: usage.GetKind(), Namespace: usage.GetNamespace(), Name: usage.GetName(), By: refs.By, Reason: refs.Reason}, true
+}
+
+// UsageRefs are the resources a Crossplane Usage involves.
+type UsageRefs struct {
+	// Of is the resource the Usage protects from deletion.
+	Of object.Reference
+	// By is the resource that uses it, if any.
+	By     *object.Reference
+	Reason string
+}
+
+// ParseUsage returns the resources the supplied Usage involves. A reference
+// without a namespace is in the namespace of the Usage, if it has one.
+func ParseUsage(usage *unstructured.Unstructured) UsageRefs {
+	pv := fieldpath.Pave(usage.Object)
+	str := func(path string) string {
+		s, _ := pv.GetString(path)
+		return s
+	}
+	ref := func(path string) object.Reference {
+		return object.Reference{
+			APIVersion: str(path + ".apiVersion"),
+			Kind:       str(path + ".kind"),
+			Namespace:  cmp.Or(str(path+".resourceRef.namespace"), usage.GetNamespace()),
+			Name:       str(path + ".resourceRef.name"),
+		}
+	}
+
+	refs := UsageRefs{Of: ref("spec.of"), Reason: str("spec.reason")}
+	if by := ref("spec.by"); by.Kind != "" {
+		refs.By = &by
+	}
+	return refs
+}
+
+// dependents returns the composed resources of the supplied composite
+// resource, or the composite resource of the supplied claim, that still
+// exist.
+func (e *Explainer) dependents(ctx context.Context, u *unstructured.Unstructured) ([]Dependent, error) {
+	var refs []object.Reference
+	if v, ok := composition.XRField(u, "resourceRefs"); ok {
+		_ = fieldpath.Pave(map[string]any{"refs": v}).GetValueInto("refs", &refs)
+	}
+	var claimed object.Reference
+	if err := fieldpath.Pave(u.Object).GetValueInto("spec.resourceRef", &claimed); err == nil && claimed.Kind != "" {
+		refs = append(refs, claimed)
+	}
+
+	out := []Dependent{}
+	for _, ref := range refs {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid apiVersion of %s %s", ref.Kind, ref.Name)
+		}
+		// Composed resources of a namespaced composite resource share its
+		// namespace.
+		ref.Namespace = cmp.Or(ref.Namespace, u.GetNamespace())
+		d, err := e.obj.Get(ctx, gv.WithKind(ref.Kind), types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name})
+		// A dependent whose kind is no longer served can't exist.
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Dependent{
+			Reference:  object.ReferenceTo(d),
+			Deleting:   d.GetDeletionTimestamp() != nil,
+			Finalizers: d.GetFinalizers(),
+		})
+	}
+	return out, nil
+}
+
+// reasons summarizes why the deletion explained by the supplied explanation
+// is blocked.
+func reasons(ex *Explanation) []string {
+	out := []string{}
+	if !ex.Deleting {
+		out = append(out, fmt.Sprintf("%s %s is not being deleted", ex.Resource.Kind, ex.Resource.Name))
+	}
+	if ex.Paused {
+		out = append(out, "reconciliation is paused by the crossplane.io/paused annotation, so Crossplane won't remove its finalizers")
+	}
+	for _, us := range ex.Usages {
+		r := fmt.Sprintf("%s %s causes deletion requests to be rejected", us.Kind, us.Name)
+		if us.By != nil {
+			r += fmt.Sprintf(" while %s %s uses it", us.By.Kind, us.By.Name)
+		}
+		out = append(out, r)
+	}
+	for _, d := range ex.Dependents {
+		state := "still exists"
+		if d.Deleting {
+			state = "is still being deleted"
+		}
+		out = append(out, fmt.Sprintf("%s %s %s", d.Kind, d.Name, state))
+	}
+	if ex.Deleting && len(ex.ManagementPolicies) > 0 && !slices.Contains(ex.ManagementPolicies, "*") && !slices.Contains(ex.ManagementPolicies, "Delete") {
+		out = append(out, "the management policies don't include Delete, so the external resource will be orphaned rather than deleted")
+	}
+	if ex.Deleting && len(ex.Errors) > 0 {
+		out = append(out, "the resource reports errors; see errors and events")
+	}
+	if ex.Deleting && len(out) == 0 && len(ex.Finalizers) > 0 {
+		out = append(out, "waiting for the controllers that own the remaining finalizers to remove them")
+	}
+	return out
+}
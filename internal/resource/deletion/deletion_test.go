@@ -0,0 +1,232 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package deletion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var xrGVK = schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XNetwork"}
+
+func xr() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "XNetwork",
+		"metadata": map[string]any{
+			"name":              "my-net",
+			"deletionTimestamp": "2025-01-02T00:00:00Z",
+			"finalizers":        []any{"composite.apiextensions.crossplane.io"},
+		},
+		"spec": map[string]any{
+			"crossplane": map[string]any{
+				"resourceRefs": []any{
+					map[string]any{"apiVersion": "ec2.aws.upbound.io/v1beta1", "kind": "VPC", "name": "my-net-vpc"},
+					map[string]any{"apiVersion": "ec2.aws.upbound.io/v1beta1", "kind": "Subnet", "name": "my-net-gone"},
+				},
+			},
+		},
+	}}
+}
+
+func vpc() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "ec2.aws.upbound.io/v1beta1",
+		"kind":       "VPC",
+		"metadata": map[string]any{
+			"name":              "my-net-vpc",
+			"deletionTimestamp": "2025-01-02T00:00:00Z",
+			"finalizers":        []any{"finalizer.managedresource.crossplane.io"},
+		},
+		"spec": map[string]any{
+			"deletionPolicy":     "Delete",
+			"managementPolicies": []any{"Observe", "Create", "Update"},
+		},
+		"status": map[string]any{
+			"conditions": []any{map[string]any{
+				"type":    "Synced",
+				"status":  "False",
+				"reason":  "ReconcileError",
+				"message": "delete failed: DependencyViolation",
+			}},
+		},
+	}}
+}
+
+func usage() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "protection.crossplane.io/v1beta1",
+		"kind":       "ClusterUsage",
+		"metadata":   map[string]any{"name": "protect-vpc"},
+		"spec": map[string]any{
+			"of": map[string]any{
+				"apiVersion":  "ec2.aws.upbound.io/v1beta1",
+				"kind":        "VPC",
+				"resourceRef": map[string]any{"name": "my-net-vpc"},
+			},
+			"by": map[string]any{
+				"apiVersion":  "example.org/v1",
+				"kind":        "XCluster",
+				"resourceRef": map[string]any{"name": "my-cluster"},
+			},
+		},
+	}}
+}
+
+func TestExplain(t *testing.T) {
+	objs := []*unstructured.Unstructured{xr(), vpc(), usage()}
+
+	cases := map[string]struct {
+		reason string
+		gvk    schema.GroupVersionKind
+		name   string
+		want   *Explanation
+	}{
+		"CompositeResource": {
+			reason: "A composite resource should be blocked by the composed resources that still exist.",
+			gvk:    xrGVK,
+			name:   "my-net",
+			want: &Explanation{
+				Resource:          object.Summary{APIVersion: "example.org/v1", Kind: "XNetwork", Name: "my-net"},
+				Deleting:          true,
+				DeletionTimestamp: "2025-01-02T00:00:00Z",
+				Finalizers:        []Finalizer{{Name: "composite.apiextensions.crossplane.io", Meaning: finalizers["composite.apiextensions.crossplane.io"]}},
+				Usages:            []Usage{},
+				Dependents: []Dependent{{
+					Reference:  object.Reference{APIVersion: "ec2.aws.upbound.io/v1beta1", Kind: "VPC", Name: "my-net-vpc"},
+					Deleting:   true,
+					Finalizers: []string{"finalizer.managedresource.crossplane.io"},
+				}},
+				Errors:  []string{},
+				Reasons: []string{"VPC my-net-vpc is still being deleted"},
+			},
+		},
+		"ManagedResource": {
+			reason: "A managed resource should report its Usages, deletion errors and management policies.",
+			gvk:    schema.GroupVersionKind{Group: "ec2.aws.upbound.io", Version: "v1beta1", Kind: "VPC"},
+			name:   "my-net-vpc",
+			want: &Explanation{
+				Resource: object.Summary{
+					APIVersion: "ec2.aws.upbound.io/v1beta1",
+					Kind:       "VPC",
+					Name:       "my-net-vpc",
+					Conditions: []object.Condition{{Type: "Synced", Status: "False", Reason: "ReconcileError", Message: "delete failed: DependencyViolation"}},
+				},
+				Deleting:          true,
+				DeletionTimestamp: "2025-01-02T00:00:00Z",
+				Finalizers:        []Finalizer{{Name: "finalizer.managedresource.crossplane.io", Meaning: finalizers["finalizer.managedresource.crossplane.io"]}},
+				Usages: []Usage{{
+					Kind: "ClusterUsage",
+					Name: "protect-vpc",
+					By:   &object.Reference{APIVersion: "example.org/v1", Kind: "XCluster", Name: "my-cluster"},
+				}},
+				Dependents:         []Dependent{},
+				DeletionPolicy:     "Delete",
+				ManagementPolicies: []string{"Observe", "Create", "Update"},
+				Errors:             []string{"Synced: delete failed: DependencyViolation"},
+				Reasons: []string{
+					"ClusterUsage protect-vpc causes deletion requests to be rejected while XCluster my-cluster uses it",
+					"the management policies don't include Delete, so the external resource will be orphaned rather than deleted",
+					"the resource reports errors; see errors and events",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := New(objectfake.NewClient(nil, objs))
+			got, err := e.Explain(context.Background(), tc.gvk, types.NamespacedName{Name: tc.name})
+			if err != nil {
+				t.Fatalf("\n%s\nExplain(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Explanation{}, "Events")); diff != "" {
+				t.Errorf("\n%s\nExplain(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUsageOf(t *testing.T) {
+	db := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "Database",
+		"metadata":   map[string]any{"name": "db", "namespace": "team-a"},
+	}}
+	protecting := func(namespace string, of map[string]any) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "protection.crossplane.io/v1beta1",
+			"kind":       "Usage",
+			"metadata":   map[string]any{"name": "protect-db", "namespace": namespace},
+			"spec":       map[string]any{"of": of, "reason": "production"},
+		}}
+		if namespace == "" {
+			u.SetKind("ClusterUsage")
+		}
+		return u
+	}
+
+	cases := map[string]struct {
+		reason string
+		usage  *unstructured.Unstructured
+		want   bool
+	}{
+		"SameNamespace": {
+			reason: "A Usage should protect a resource in its own namespace.",
+			usage:  protecting("team-a", map[string]any{"apiVersion": "example.org/v1", "kind": "Database", "resourceRef": map[string]any{"name": "db"}}),
+			want:   true,
+		},
+		"OtherVersion": {
+			reason: "A Usage should protect a resource referenced at another version of its kind.",
+			usage:  protecting("team-a", map[string]any{"apiVersion": "example.org/v1beta1", "kind": "Database", "resourceRef": map[string]any{"name": "db"}}),
+			want:   true,
+		},
+		"ResourceRefNamespace": {
+			reason: "A ClusterUsage should protect the resource in the namespace its resourceRef names.",
+			usage:  protecting("", map[string]any{"apiVersion": "example.org/v1", "kind": "Database", "resourceRef": map[string]any{"name": "db", "namespace": "team-a"}}),
+			want:   true,
+		},
+		"OtherResourceRefNamespace": {
+			reason: "A Usage shouldn't protect a resource of the same name in another namespace.",
+			usage:  protecting("", map[string]any{"apiVersion": "example.org/v1", "kind": "Database", "resourceRef": map[string]any{"name": "db", "namespace": "team-b"}}),
+			want:   false,
+		},
+		"OtherGroup": {
+			reason: "A Usage shouldn't protect a resource of the same kind in another group.",
+			usage:  protecting("team-a", map[string]any{"apiVersion": "other.org/v1", "kind": "Database", "resourceRef": map[string]any{"name": "db"}}),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, got := usageOf(tc.usage, db)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nusageOf(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
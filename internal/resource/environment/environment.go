@@ -0,0 +1,431 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package environment provides tool helpers for resolving the
+EnvironmentConfigs a composite resource's Composition selects.
+*/
+package environment
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// TypeReference selects an EnvironmentConfig by name.
+	TypeReference = "Reference"
+	// TypeSelector selects EnvironmentConfigs by label.
+	TypeSelector = "Selector"
+
+	// ModeSingle selects exactly one EnvironmentConfig.
+	ModeSingle = "Single"
+	// ModeMultiple selects any number of EnvironmentConfigs.
+	ModeMultiple = "Multiple"
+
+	// labelFromFieldPath takes a label's value from a composite resource
+	// field.
+	labelFromFieldPath = "FromCompositeFieldPath"
+	// labelValue takes a label's value from a literal.
+	labelValue = "Value"
+
+	// policyOptional allows a selection to resolve nothing.
+	policyOptional = "Optional"
+
+	// defaultSortPath is the field selected EnvironmentConfigs are sorted by
+	// unless the selector says otherwise.
+	defaultSortPath = "metadata.name"
+
+	// originComposition is the origin of the environment selected by a
+	// Composition rather than by a pipeline step.
+	originComposition = "spec.environment"
+)
+
+// GVK returns the GroupVersionKind of an EnvironmentConfig.
+func GVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: "v1beta1", Kind: "EnvironmentConfig"}
+}
+
+// Resolution describes the environment of a composite resource.
+type Resolution struct {
+	Composite   object.Summary `json:"composite"`
+	Composition string         `json:"composition,omitempty"`
+	// Recorded are the EnvironmentConfigs the composite resource records it
+	// selected, if any.
+	Recorded []string `json:"recorded,omitempty"`
+	// Sources of the environment: the Composition's spec.environment or the
+	// pipeline steps that load EnvironmentConfigs.
+	Sources []Source `json:"sources"`
+}
+
+// Source is a place in a Composition that selects EnvironmentConfigs.
+type Source struct {
+	// Origin is spec.environment or the name of a pipeline step.
+	Origin      string         `json:"origin"`
+	Resolution  string         `json:"resolution,omitempty"`
+	DefaultData map[string]any `json:"defaultData,omitempty"`
+	Selections  []Selection    `json:"selections"`
+	// Configs are the selected EnvironmentConfigs, in the order they are
+	// merged.
+	Configs []Config `json:"configs"`
+	// Merged is the environment produced by merging the default data and
+	// the data of every selected EnvironmentConfig, in order.
+	Merged map[string]any `json:"merged"`
+	Errors []string       `json:"errors"`
+}
+
+// Selection is an entry of a Source's environmentConfigs.
+type Selection struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	// Ref is the name of the referenced EnvironmentConfig.
+	Ref  string `json:"ref,omitempty"`
+	Mode string `json:"mode,omitempty"`
+	// Labels are the resolved label values a selector matches.
+	Labels   map[string]string `json:"labels,omitempty"`
+	Selected []string          `json:"selected"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// Config is a selected EnvironmentConfig.
+type Config struct {
+	Name string         `json:"name"`
+	Data map[string]any `json:"data,omitempty"`
+}
+
+// spec is how EnvironmentConfigs are selected, by a Composition's
+// spec.environment or by the input of the function that loads them.
+type spec struct {
+	EnvironmentConfigs []struct {
+		Type string `json:"type"`
+		Ref  *struct {
+			Name string `json:"name"`
+		} `json:"ref"`
+		Selector *selector `json:"selector"`
+	} `json:"environmentConfigs"`
+	DefaultData map[string]any `json:"defaultData"`
+	Policy      *struct {
+		Resolution string `json:"resolution"`
+	} `json:"policy"`
+}
+
+// selector selects EnvironmentConfigs by label.
+type selector struct {
+	Mode            string       `json:"mode"`
+	SortByFieldPath string       `json:"sortByFieldPath"`
+	MaxMatch        *int         `json:"maxMatch"`
+	MinMatch        *int         `json:"minMatch"`
+	MatchLabels     []matchLabel `json:"matchLabels"`
+}
+
+// matchLabel is a label an EnvironmentConfig must have to be selected.
+type matchLabel struct {
+	Key                 string  `json:"key"`
+	Type                string  `json:"type"`
+	ValueFromFieldPath  *string `json:"valueFromFieldPath"`
+	Value               *string `json:"value"`
+	FromFieldPathPolicy string  `json:"fromFieldPathPolicy"`
+}
+
+// Resolver resolves the environment of composite resources in the
+// configured controlplane.
+type Resolver struct {
+	log  logging.Logger
+	obj  *object.Client
+	comp *composition.Compositions
+}
+
+// Option modifies the underlying Resolver.
+type Option func(*Resolver)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(r *Resolver) {
+		r.log = log
+	}
+}
+
+// New constructs a new Resolver.
+func New(obj *object.Client, comp *composition.Compositions, opts ...Option) *Resolver {
+	r := &Resolver{
+		log:  logging.NewNopLogger(),
+		obj:  obj,
+		comp: comp,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// Resolve the EnvironmentConfigs selected for the supplied composite
+// resource the way Crossplane, or function-environment-configs, would.
+func (r *Resolver) Resolve(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Resolution, error) {
+	xr, err := r.comp.GetComposite(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	comp, err := r.comp.Selected(ctx, xr)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Resolution{
+		Composite:   object.Summarize(xr),
+		Composition: comp.GetName(),
+		Sources:     []Source{},
+	}
+	var recorded []struct {
+		Name string `json:"name"`
+	}
+	_ = fieldpath.Pave(xr.Object).GetValueInto("spec.environmentConfigRefs", &recorded)
+	for _, ref := range recorded {
+		res.Recorded = append(res.Recorded, ref.Name)
+	}
+
+	pv := fieldpath.Pave(comp.Object)
+	var env spec
+	if err := pv.GetValueInto(originComposition, &env); err == nil {
+		res.Sources = append(res.Sources, r.resolve(ctx, xr, originComposition, env))
+	}
+
+	var steps []struct {
+		Step  string `json:"step"`
+		Input *struct {
+			Spec *spec `json:"spec"`
+		} `json:"input"`
+	}
+	_ = pv.GetValueInto("spec.pipeline", &steps)
+	for _, s := range steps {
+		if s.Input == nil || s.Input.Spec == nil || len(s.Input.Spec.EnvironmentConfigs) == 0 {
+			continue
+		}
+		res.Sources = append(res.Sources, r.resolve(ctx, xr, s.Step, *s.Input.Spec))
+	}
+
+	if len(res.Sources) == 0 {
+		return nil, errors.Errorf("%s %s does not select EnvironmentConfigs", comp.GetKind(), comp.GetName())
+	}
+	return res, nil
+}
+
+// resolve the EnvironmentConfigs selected by the supplied source.
+func (r *Resolver) resolve(ctx context.Context, xr *unstructured.Unstructured, origin string, env spec) Source {
+	src := Source{
+		Origin:      origin,
+		DefaultData: env.DefaultData,
+		Selections:  []Selection{},
+		Configs:     []Config{},
+		Merged:      map[string]any{},
+		Errors:      []string{},
+	}
+	if env.Policy != nil {
+		src.Resolution = env.Policy.Resolution
+	}
+	merge(src.Merged, env.DefaultData)
+
+	var all []unstructured.Unstructured
+	listed := false
+	for i, ec := range env.EnvironmentConfigs {
+		sel := Selection{Index: i, Type: cmp.Or(ec.Type, TypeReference), Selected: []string{}}
+
+		var configs []unstructured.Unstructured
+		var err error
+		switch {
+		case sel.Type == TypeReference && ec.Ref != nil:
+			sel.Ref = ec.Ref.Name
+			var u *unstructured.Unstructured
+			u, err = r.obj.Get(ctx, GVK(), types.NamespacedName{Name: ec.Ref.Name})
+			switch {
+			case kerrors.IsNotFound(err) && src.Resolution == policyOptional:
+				err = nil
+			case kerrors.IsNotFound(err):
+				err = errors.Errorf("EnvironmentConfig %s does not exist", ec.Ref.Name)
+			case err == nil:
+				configs = []unstructured.Unstructured{*u}
+			}
+		case sel.Type == TypeSelector && ec.Selector != nil:
+			if !listed {
+				if all, err = r.obj.List(ctx, GVK(), "", metav1.ListOptions{}); err == nil {
+					listed = true
+				}
+			}
+			if err == nil {
+				configs, err = r.selectConfigs(xr, &sel, ec.Selector, all, src.Resolution == policyOptional)
+			}
+		case sel.Type == TypeReference:
+			err = errors.New("no ref is specified")
+		case sel.Type == TypeSelector:
+			err = errors.New("no selector is specified")
+		default:
+			err = errors.Errorf("unknown type %s", sel.Type)
+		}
+
+		if err != nil {
+			sel.Error = err.Error()
+			src.Errors = append(src.Errors, fmt.Sprintf("environmentConfigs[%d]: %s", i, err))
+		}
+		for _, c := range configs {
+			data, _ := c.Object["data"].(map[string]any)
+			sel.Selected = append(sel.Selected, c.GetName())
+			src.Configs = append(src.Configs, Config{Name: c.GetName(), Data: data})
+			merge(src.Merged, data)
+		}
+		src.Selections = append(src.Selections, sel)
+	}
+	return src
+}
+
+// selectConfigs returns the EnvironmentConfigs the supplied selector
+// selects, in the order they're merged.
+func (r *Resolver) selectConfigs(xr *unstructured.Unstructured, sel *Selection, s *selector, all []unstructured.Unstructured, optional bool) ([]unstructured.Unstructured, error) {
+	sel.Mode = cmp.Or(s.Mode, ModeSingle)
+	sel.Labels = map[string]string{}
+
+	xp := fieldpath.Pave(xr.Object)
+	for _, l := range s.MatchLabels {
+		switch cmp.Or(l.Type, labelFromFieldPath) {
+		case labelValue:
+			if l.Value == nil {
+				return nil, errors.Errorf("label %s has no value", l.Key)
+			}
+			sel.Labels[l.Key] = *l.Value
+		case labelFromFieldPath:
+			if l.ValueFromFieldPath == nil {
+				return nil, errors.Errorf("label %s has no valueFromFieldPath", l.Key)
+			}
+			v, err := xp.GetValue(*l.ValueFromFieldPath)
+			if err != nil {
+				if l.FromFieldPathPolicy == policyOptional {
+					continue
+				}
+				return nil, errors.Wrapf(err, "cannot resolve label %s from composite field %s", l.Key, *l.ValueFromFieldPath)
+			}
+			sel.Labels[l.Key] = fmt.Sprint(v)
+		}
+	}
+
+	var matched []unstructured.Unstructured
+	for _, c := range all {
+		if matches(c.GetLabels(), sel.Labels) {
+			matched = append(matched, c)
+		}
+	}
+
+	sortBy := cmp.Or(s.SortByFieldPath, defaultSortPath)
+	var sortErr error
+	slices.SortStableFunc(matched, func(a, b unstructured.Unstructured) int {
+		av, aerr := fieldpath.Pave(a.Object).GetValue(sortBy)
+		bv, berr := fieldpath.Pave(b.Object).GetValue(sortBy)
+		if aerr != nil || berr != nil {
+			sortErr = errors.Errorf("cannot sort EnvironmentConfigs by %s", sortBy)
+			return 0
+		}
+		return compare(av, bv)
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	switch sel.Mode {
+	case ModeSingle:
+		switch {
+		case len(matched) == 1:
+			return matched, nil
+		case len(matched) == 0 && optional:
+			return nil, nil
+		default:
+			return nil, errors.Errorf("Single mode selector matched %d EnvironmentConfigs, want 1", len(matched))
+		}
+	case ModeMultiple:
+		if s.MaxMatch != nil && len(matched) > *s.MaxMatch {
+			matched = matched[:*s.MaxMatch]
+		}
+		if s.MinMatch != nil && len(matched) < *s.MinMatch {
+			return nil, errors.Errorf("selector matched %d EnvironmentConfigs, want at least %d", len(matched), *s.MinMatch)
+		}
+		if len(matched) == 0 && !optional {
+			return nil, errors.New("selector matched no EnvironmentConfigs")
+		}
+		return matched, nil
+	}
+	return nil, errors.Errorf("unknown selector mode %s", sel.Mode)
+}
+
+// matches returns true if the supplied labels include every wanted label.
+func matches(labels, want map[string]string) bool {
+	for k, v := range want {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// compare the supplied values of a sort field. Numbers are compared
+// numerically and everything else as strings.
+func compare(a, b any) int {
+	af, aok := object.Number(a)
+	bf, bok := object.Number(b)
+	if aok && bok {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// merge src into dst. Objects are merged recursively; any other value in
+// src replaces the value in dst.
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		sm, sok := v.(map[string]any)
+		dm, dok := dst[k].(map[string]any)
+		if sok && dok {
+			merge(dm, sm)
+			continue
+		}
+		if sok {
+			cp := map[string]any{}
+			merge(cp, sm)
+			dst[k] = cp
+			continue
+		}
+		dst[k] = v
+	}
+}
@@ -0,0 +1,169 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package environment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var xrGVK = schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XApp"}
+
+func xr() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "XApp",
+		"metadata":   map[string]any{"name": "my-app"},
+		"spec": map[string]any{
+			"region":         "eu-west-1",
+			"compositionRef": map[string]any{"name": "app"},
+		},
+	}}
+}
+
+func comp(environmentConfigs ...any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "Composition",
+		"metadata":   map[string]any{"name": "app"},
+		"spec": map[string]any{
+			"mode": "Pipeline",
+			"pipeline": []any{
+				map[string]any{
+					"step":        "environment",
+					"functionRef": map[string]any{"name": "function-environment-configs"},
+					"input": map[string]any{
+						"apiVersion": "environmentconfigs.fn.crossplane.io/v1beta1",
+						"kind":       "Input",
+						"spec": map[string]any{
+							"defaultData":        map[string]any{"tier": "dev", "network": map[string]any{"cidr": "10.0.0.0/16"}},
+							"environmentConfigs": environmentConfigs,
+						},
+					},
+				},
+				map[string]any{"step": "patch", "functionRef": map[string]any{"name": "function-patch-and-transform"}},
+			},
+		},
+	}}
+}
+
+func ec(name string, labels map[string]any, data map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1beta1",
+		"kind":       "EnvironmentConfig",
+		"metadata":   map[string]any{"name": name, "labels": labels},
+		"data":       data,
+	}}
+}
+
+func TestResolve(t *testing.T) {
+	ecs := []*unstructured.Unstructured{
+		ec("base", nil, map[string]any{"tier": "prod"}),
+		ec("eu-b", map[string]any{"region": "eu-west-1"}, map[string]any{"network": map[string]any{"subnet": "b"}}),
+		ec("eu-a", map[string]any{"region": "eu-west-1"}, map[string]any{"network": map[string]any{"subnet": "a"}}),
+		ec("us", map[string]any{"region": "us-east-1"}, map[string]any{"network": map[string]any{"subnet": "us"}}),
+	}
+	ref := func(name string) any {
+		return map[string]any{"type": "Reference", "ref": map[string]any{"name": name}}
+	}
+	bySelector := func(mode string) any {
+		return map[string]any{
+			"type": "Selector",
+			"selector": map[string]any{
+				"mode": mode,
+				"matchLabels": []any{map[string]any{
+					"key":                "region",
+					"type":               "FromCompositeFieldPath",
+					"valueFromFieldPath": "spec.region",
+				}},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		comp   *unstructured.Unstructured
+		want   []Source
+	}{
+		"Merged": {
+			reason: "EnvironmentConfigs selected by reference and label should be merged over the default data, in order.",
+			comp:   comp(ref("base"), bySelector("Multiple")),
+			want: []Source{{
+				Origin:      "environment",
+				DefaultData: map[string]any{"tier": "dev", "network": map[string]any{"cidr": "10.0.0.0/16"}},
+				Selections: []Selection{
+					{Index: 0, Type: "Reference", Ref: "base", Selected: []string{"base"}},
+					{Index: 1, Type: "Selector", Mode: "Multiple", Labels: map[string]string{"region": "eu-west-1"}, Selected: []string{"eu-a", "eu-b"}},
+				},
+				Configs: []Config{
+					{Name: "base", Data: map[string]any{"tier": "prod"}},
+					{Name: "eu-a", Data: map[string]any{"network": map[string]any{"subnet": "a"}}},
+					{Name: "eu-b", Data: map[string]any{"network": map[string]any{"subnet": "b"}}},
+				},
+				Merged: map[string]any{"tier": "prod", "network": map[string]any{"cidr": "10.0.0.0/16", "subnet": "b"}},
+				Errors: []string{},
+			}},
+		},
+		"SelectionErrors": {
+			reason: "A missing reference and a Single mode selector matching many EnvironmentConfigs should be reported.",
+			comp:   comp(ref("missing"), bySelector("Single")),
+			want: []Source{{
+				Origin:      "environment",
+				DefaultData: map[string]any{"tier": "dev", "network": map[string]any{"cidr": "10.0.0.0/16"}},
+				Selections: []Selection{
+					{Index: 0, Type: "Reference", Ref: "missing", Selected: []string{}, Error: "EnvironmentConfig missing does not exist"},
+					{Index: 1, Type: "Selector", Mode: "Single", Labels: map[string]string{"region": "eu-west-1"}, Selected: []string{}, Error: "Single mode selector matched 2 EnvironmentConfigs, want 1"},
+				},
+				Configs: []Config{},
+				Merged:  map[string]any{"tier": "dev", "network": map[string]any{"cidr": "10.0.0.0/16"}},
+				Errors: []string{
+					"environmentConfigs[0]: EnvironmentConfig missing does not exist",
+					"environmentConfigs[1]: Single mode selector matched 2 EnvironmentConfigs, want 1",
+				},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := objectfake.NewClient(nil, append([]*unstructured.Unstructured{xr(), tc.comp}, ecs...))
+			r := New(obj, composition.New(obj))
+
+			got, err := r.Resolve(context.Background(), xrGVK, types.NamespacedName{Name: "my-app"})
+			if err != nil {
+				t.Fatalf("\n%s\nResolve(...): %v", tc.reason, err)
+			}
+			want := &Resolution{
+				Composite:   object.Summary{APIVersion: "example.org/v1", Kind: "XApp", Name: "my-app"},
+				Composition: "app",
+				Sources:     tc.want,
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package fleet provides tool helpers for sweeping every managed resource,
+composite resource and claim in the controlplane.
+*/
+package fleet
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+const (
+	// CategoryManaged is the category of managed resource kinds.
+	CategoryManaged = "managed"
+	// CategoryComposite is the category of composite resource kinds.
+	CategoryComposite = "composite"
+	// CategoryClaim is the category of claim kinds.
+	CategoryClaim = "claim"
+
+	// Conditions a healthy resource reports as True.
+	conditionReady  = "Ready"
+	conditionSynced = "Synced"
+
+	// reasonMissing is reported when a resource doesn't have a condition.
+	reasonMissing = "ConditionMissing"
+
+	// maxExamples of each group to return to the caller.
+	maxExamples = 3
+)
+
+// Categories returns the categories of resources Crossplane reconciles.
+func Categories() []string {
+	return []string{CategoryManaged, CategoryComposite, CategoryClaim}
+}
+
+// Kind is a kind of resource Crossplane reconciles.
+type Kind struct {
+	GVK      schema.GroupVersionKind
+	Category string
+	// Owner is the provider that reconciles a managed resource kind, or the
+	// CompositeResourceDefinition that defines a composite resource or claim
+	// kind.
+	Owner string
+}
+
+// Sweep is the result of sweeping the controlplane for unhealthy resources.
+type Sweep struct {
+	// Kinds is the number of kinds swept.
+	Kinds int `json:"kinds"`
+	// Scanned is the number of resources swept.
+	Scanned int `json:"scanned"`
+	// Unhealthy is the number of resources that aren't Ready or Synced.
+	Unhealthy int `json:"unhealthy"`
+	// Groups of unhealthy resources, largest first. A resource that is
+	// neither Ready nor Synced is counted in a group for each condition.
+	Groups []Group `json:"groups"`
+	// Errors listing kinds, which are skipped.
+	Errors []string `json:"errors"`
+}
+
+// Group is a group of resources that report the same condition reason.
+type Group struct {
+	Category   string `json:"category"`
+	Owner      string `json:"owner,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Condition is Ready or Synced.
+	Condition string `json:"condition"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	Count     int    `json:"count"`
+	// Examples are the first few resources in the group.
+	Examples []Example `json:"examples"`
+}
+
+// Example is an unhealthy resource.
+type Example struct {
+	object.Reference
+	Message string `json:"message,omitempty"`
+}
+
+// Fleet provides methods for sweeping the resources Crossplane reconciles in
+// the configured controlplane.
+type Fleet struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Fleet.
+type Option func(*Fleet)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(f *Fleet) {
+		f.log = log
+	}
+}
+
+// New constructs a new Fleet.
+func New(obj *object.Client, opts ...Option) *Fleet {
+	f := &Fleet{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(f)
+	}
+
+	return f
+}
+
+// Kinds returns the kinds in the supplied categories, found by the
+// categories of the CustomResourceDefinitions that define them. Kinds are
+// returned at their storage version.
+func (f *Fleet) Kinds(ctx context.Context, categories ...string) ([]Kind, error) {
+	crds, err := crd.List(ctx, f.obj)
+	if err != nil {
+		return nil, err
+	}
+	providers := f.providers(ctx)
+
+	out := []Kind{}
+	for _, c := range crds {
+		i := slices.IndexFunc(categories, func(cat string) bool { return slices.Contains(c.Spec.Names.Categories, cat) })
+		if i < 0 {
+			continue
+		}
+		v, err := crd.Version(c, "")
+		if err != nil {
+			continue
+		}
+		k := Kind{
+			GVK:      schema.GroupVersionKind{Group: c.Spec.Group, Version: v.Name, Kind: c.Spec.Names.Kind},
+			Category: categories[i],
+			Owner:    crd.DefinedBy(c),
+		}
+		for _, ref := range c.GetOwnerReferences() {
+			if ref.Kind == xpkg.RevisionGVK(xpkg.KindProvider).Kind {
+				k.Owner = cmp.Or(providers[ref.Name], ref.Name)
+			}
+		}
+		out = append(out, k)
+	}
+	slices.SortFunc(out, func(a, b Kind) int {
+		return cmp.Or(cmp.Compare(a.Owner, b.Owner), cmp.Compare(a.GVK.Kind, b.GVK.Kind), cmp.Compare(a.GVK.Group, b.GVK.Group))
+	})
+	return out, nil
+}
+
+// providers returns the names of the providers keyed by the names of their
+// revisions. It returns an empty map if revisions can't be listed.
+func (f *Fleet) providers(ctx context.Context) map[string]string {
+	out := map[string]string{}
+	revs, err := f.obj.List(ctx, xpkg.RevisionGVK(xpkg.KindProvider), "", metav1.ListOptions{})
+	if err != nil {
+		f.log.Debug("cannot list provider revisions", "error", err)
+		return out
+	}
+	for _, r := range revs {
+		if name := r.GetLabels()[xpkg.LabelPackage]; name != "" {
+			out[r.GetName()] = name
+		}
+	}
+	return out
+}
+
+// Unhealthy sweeps the resources in the supplied categories and groups
+// those that aren't Ready or Synced by owner, kind and condition reason.
+func (f *Fleet) Unhealthy(ctx context.Context, categories ...string) (*Sweep, error) {
+	kinds, err := f.Kinds(ctx, categories...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Sweep{Kinds: len(kinds), Groups: []Group{}, Errors: []string{}}
+	groups := map[string]*Group{}
+	for _, k := range kinds {
+		err := f.obj.Each(ctx, k.GVK, "", metav1.ListOptions{}, func(u *unstructured.Unstructured) {
+			s.Scanned++
+			if add(groups, k, u) {
+				s.Unhealthy++
+			}
+		})
+		if err != nil {
+			s.Errors = append(s.Errors, err.Error())
+		}
+	}
+
+	for _, g := range groups {
+		s.Groups = append(s.Groups, *g)
+	}
+	slices.SortFunc(s.Groups, func(a, b Group) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Owner, b.Owner), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Condition, b.Condition), cmp.Compare(a.Reason, b.Reason))
+	})
+	return s, nil
+}
+
+// add the supplied resource to the groups of each condition it isn't
+// healthy by. It returns true if the resource is unhealthy.
+func add(groups map[string]*Group, k Kind, u *unstructured.Unstructured) bool {
+	cs := object.Conditions(u)
+	unhealthy := false
+	for _, t := range []string{conditionReady, conditionSynced} {
+		c, ok := object.FindCondition(cs, t)
+		if !ok {
+			c = object.Condition{Type: t, Status: string(metav1.ConditionUnknown), Reason: reasonMissing}
+		}
+		if c.Status == string(metav1.ConditionTrue) {
+			continue
+		}
+		unhealthy = true
+
+		key := fmt.Sprintf("%s/%s/%s/%s/%s", k.GVK.GroupKind(), k.Owner, c.Type, c.Status, c.Reason)
+		g, ok := groups[key]
+		if !ok {
+			g = &Group{
+				Category:   k.Category,
+				Owner:      k.Owner,
+				APIVersion: k.GVK.GroupVersion().String(),
+				Kind:       k.GVK.Kind,
+				Condition:  c.Type,
+				Status:     c.Status,
+				Reason:     c.Reason,
+				Examples:   []Example{},
+			}
+			groups[key] = g
+		}
+		g.Count++
+		if len(g.Examples) < maxExamples {
+			g.Examples = append(g.Examples, Example{Reference: object.ReferenceTo(u), Message: c.Message})
+		}
+	}
+	return unhealthy
+}
@@ -0,0 +1,219 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+func crdOf(group, kind, plural, category string, owner metav1.OwnerReference) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": plural + "." + group},
+		"spec": map[string]any{
+			"group": group,
+			"names": map[string]any{"kind": kind, "plural": plural, "categories": []any{"crossplane", category}},
+			"scope": "Cluster",
+			"versions": []any{
+				map[string]any{"name": "v1alpha1", "served": true, "storage": false},
+				map[string]any{"name": "v1beta1", "served": true, "storage": true},
+			},
+		},
+	}}
+	u.SetOwnerReferences([]metav1.OwnerReference{owner})
+	return u
+}
+
+func resource(apiVersion, kind, name string, conditions ...any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name},
+		"status":     map[string]any{"conditions": conditions},
+	}}
+}
+
+func condition(t, status, reason, message string) any {
+	return map[string]any{"type": t, "status": status, "reason": reason, "message": message}
+}
+
+func TestUnhealthy(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		crdOf("s3.aws.upbound.io", "Bucket", "buckets", CategoryManaged, metav1.OwnerReference{Kind: "ProviderRevision", Name: "provider-aws-s3-abc"}),
+		crdOf("example.org", "XDatabase", "xdatabases", CategoryComposite, metav1.OwnerReference{Kind: "CompositeResourceDefinition", Name: "xdatabases.example.org"}),
+		{Object: map[string]any{
+			"apiVersion": "pkg.crossplane.io/v1",
+			"kind":       "ProviderRevision",
+			"metadata":   map[string]any{"name": "provider-aws-s3-abc", "labels": map[string]any{xpkg.LabelPackage: "provider-aws-s3"}},
+		}},
+		resource("s3.aws.upbound.io/v1beta1", "Bucket", "healthy",
+			condition("Ready", "True", "Available", ""),
+			condition("Synced", "True", "ReconcileSuccess", "")),
+		resource("s3.aws.upbound.io/v1beta1", "Bucket", "creating",
+			condition("Ready", "False", "Creating", ""),
+			condition("Synced", "True", "ReconcileSuccess", "")),
+		resource("s3.aws.upbound.io/v1beta1", "Bucket", "failing",
+			condition("Ready", "False", "Creating", ""),
+			condition("Synced", "False", "ReconcileError", "access denied")),
+		resource("example.org/v1beta1", "XDatabase", "new"),
+	}
+
+	type want struct {
+		sweep *Sweep
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason     string
+		categories []string
+		want       want
+	}{
+		"AllCategories": {
+			reason:     "Resources that aren't Ready or Synced should be grouped by owner, kind and condition reason.",
+			categories: Categories(),
+			want: want{sweep: &Sweep{
+				Kinds:     2,
+				Scanned:   4,
+				Unhealthy: 3,
+				Groups: []Group{
+					{
+						Category: CategoryManaged, Owner: "provider-aws-s3", APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket",
+						Condition: "Ready", Status: "False", Reason: "Creating", Count: 2,
+						Examples: []Example{
+							{Reference: object.Reference{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket", Name: "creating"}},
+							{Reference: object.Reference{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket", Name: "failing"}},
+						},
+					},
+					{
+						Category: CategoryManaged, Owner: "provider-aws-s3", APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket",
+						Condition: "Synced", Status: "False", Reason: "ReconcileError", Count: 1,
+						Examples: []Example{
+							{Reference: object.Reference{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket", Name: "failing"}, Message: "access denied"},
+						},
+					},
+					{
+						Category: CategoryComposite, Owner: "xdatabases.example.org", APIVersion: "example.org/v1beta1", Kind: "XDatabase",
+						Condition: "Ready", Status: "Unknown", Reason: reasonMissing, Count: 1,
+						Examples: []Example{{Reference: object.Reference{APIVersion: "example.org/v1beta1", Kind: "XDatabase", Name: "new"}}},
+					},
+					{
+						Category: CategoryComposite, Owner: "xdatabases.example.org", APIVersion: "example.org/v1beta1", Kind: "XDatabase",
+						Condition: "Synced", Status: "Unknown", Reason: reasonMissing, Count: 1,
+						Examples: []Example{{Reference: object.Reference{APIVersion: "example.org/v1beta1", Kind: "XDatabase", Name: "new"}}},
+					},
+				},
+				Errors: []string{},
+			}},
+		},
+		"ClaimsOnly": {
+			reason:     "Only kinds in the requested categories should be swept.",
+			categories: []string{CategoryClaim},
+			want:       want{sweep: &Sweep{Groups: []Group{}, Errors: []string{}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := New(objectfake.NewClient(nil, objs))
+
+			got, err := f.Unhealthy(context.Background(), tc.categories...)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nUnhealthy(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.sweep, got); diff != "" {
+				t.Errorf("\n%s\nUnhealthy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// paged serves the buckets it holds a page at a time, honouring limit and
+// continue like an API server. The fake dynamic client ignores both.
+type paged struct {
+	dynamic.Interface
+	buckets []unstructured.Unstructured
+	limits  *[]int64
+}
+
+func (p paged) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	if gvr.Resource != "buckets" {
+		return p.Interface.Resource(gvr)
+	}
+	return pagedResource{NamespaceableResourceInterface: p.Interface.Resource(gvr), paged: p}
+}
+
+type pagedResource struct {
+	dynamic.NamespaceableResourceInterface
+	paged paged
+}
+
+func (r pagedResource) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	*r.paged.limits = append(*r.paged.limits, opts.Limit)
+	start, _ := strconv.Atoi(opts.Continue)
+	end := min(start+int(opts.Limit)/20, len(r.paged.buckets))
+	l := &unstructured.UnstructuredList{Items: r.paged.buckets[start:end]}
+	if end < len(r.paged.buckets) {
+		l.SetContinue(strconv.Itoa(end))
+	}
+	return l, nil
+}
+
+func TestUnhealthyLarge(t *testing.T) {
+	crd := crdOf("s3.aws.upbound.io", "Bucket", "buckets", CategoryManaged, metav1.OwnerReference{Kind: "ProviderRevision", Name: "provider-aws-s3-abc"})
+	buckets := make([]unstructured.Unstructured, 0, 60)
+	for i := range 60 {
+		buckets = append(buckets, *resource("s3.aws.upbound.io/v1beta1", "Bucket", fmt.Sprintf("bucket-%02d", i),
+			condition("Ready", "False", fmt.Sprintf("Reason%02d", i), ""),
+			condition("Synced", "True", "ReconcileSuccess", "")))
+	}
+
+	kinds := append(objectfake.KindsOf([]*unstructured.Unstructured{crd, &buckets[0]}), objectfake.Kind{GVK: xpkg.RevisionGVK(xpkg.KindProvider)})
+	limits := []int64{}
+	dyn := paged{Interface: objectfake.Dynamic(kinds, []*unstructured.Unstructured{crd}), buckets: buckets, limits: &limits}
+	f := New(object.New(fake.NewClientset(), dyn, objectfake.Mapper(kinds...)))
+
+	got, err := f.Unhealthy(context.Background(), CategoryManaged)
+	if err != nil {
+		t.Fatalf("Unhealthy(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(60, got.Scanned); diff != "" {
+		t.Errorf("Unhealthy(...): every page of resources should be scanned: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]int64{500, 500, 500}, limits); diff != "" {
+		t.Errorf("Unhealthy(...): resources should be listed a page at a time: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(60, len(got.Groups)); diff != "" {
+		t.Errorf("Unhealthy(...): every group should be returned for the caller to page through: -want, +got:\n%s", diff)
+	}
+}
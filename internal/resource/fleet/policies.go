@@ -0,0 +1,159 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// policyAll is the default management policy, which allows every
+	// action.
+	policyAll = "*"
+	// policyObserve is the management policy that allows observing the
+	// external resource.
+	policyObserve = "Observe"
+)
+
+// policyActions are the actions a managed resource takes when its
+// management policies allow them.
+var policyActions = []string{policyObserve, "Create", "Update", "Delete", "LateInitialize"}
+
+// Overrides are the resources whose reconciliation is intentionally
+// limited.
+type Overrides struct {
+	// Kinds is the number of kinds swept.
+	Kinds int `json:"kinds"`
+	// Scanned is the number of resources swept.
+	Scanned int `json:"scanned"`
+	// Paused is the number of resources with the crossplane.io/paused
+	// annotation.
+	Paused int `json:"paused"`
+	// ObserveOnly is the number of managed resources that only observe
+	// their external resource.
+	ObserveOnly int `json:"observeOnly"`
+	// Limited is the number of other managed resources with non-default
+	// management policies.
+	Limited   int        `json:"limited"`
+	Resources []Override `json:"resources"`
+	// Errors listing kinds, which are skipped.
+	Errors []string `json:"errors"`
+}
+
+// Override is a resource whose reconciliation is intentionally limited.
+type Override struct {
+	object.Reference
+	Category           string   `json:"category"`
+	Owner              string   `json:"owner,omitempty"`
+	Paused             bool     `json:"paused"`
+	ObserveOnly        bool     `json:"observeOnly"`
+	ManagementPolicies []string `json:"managementPolicies,omitempty"`
+	// Effect explains what Crossplane won't do for the resource.
+	Effect string `json:"effect"`
+}
+
+// Overrides sweeps the resources in the supplied categories for those that
+// are paused or whose management policies differ from the default.
+func (f *Fleet) Overrides(ctx context.Context, categories ...string) (*Overrides, error) {
+	kinds, err := f.Kinds(ctx, categories...)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Overrides{Kinds: len(kinds), Resources: []Override{}, Errors: []string{}}
+	for _, k := range kinds {
+		err := f.obj.Each(ctx, k.GVK, "", metav1.ListOptions{}, func(u *unstructured.Unstructured) {
+			o.Scanned++
+			ov, ok := override(k, u)
+			if !ok {
+				return
+			}
+			switch {
+			case ov.Paused:
+				o.Paused++
+			case ov.ObserveOnly:
+				o.ObserveOnly++
+			default:
+				o.Limited++
+			}
+			o.Resources = append(o.Resources, ov)
+		})
+		if err != nil {
+			o.Errors = append(o.Errors, err.Error())
+		}
+	}
+	return o, nil
+}
+
+// override returns the supplied resource if it is paused or has non-default
+// management policies.
+func override(k Kind, u *unstructured.Unstructured) (Override, bool) {
+	policies, _ := fieldpath.Pave(u.Object).GetStringArray("spec.managementPolicies")
+	ov := Override{
+		Reference: object.ReferenceTo(u),
+		Category:  k.Category,
+		Owner:     k.Owner,
+		Paused:    meta.IsPaused(u),
+	}
+	if excluded(policies) != nil {
+		ov.ManagementPolicies = policies
+		ov.ObserveOnly = len(policies) == 1 && policies[0] == policyObserve
+	}
+	if !ov.Paused && ov.ManagementPolicies == nil {
+		return Override{}, false
+	}
+	ov.Effect = effect(ov)
+	return ov, true
+}
+
+// excluded returns the actions the supplied management policies exclude.
+// No policies are the default, which allows every action.
+func excluded(policies []string) []string {
+	if len(policies) == 0 || slices.Contains(policies, policyAll) {
+		return nil
+	}
+	var out []string
+	for _, a := range policyActions {
+		if !slices.Contains(policies, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// effect explains what Crossplane won't do for the supplied resource.
+func effect(ov Override) string {
+	switch {
+	case ov.Paused:
+		return "not reconciled until the crossplane.io/paused annotation is removed"
+	case ov.ObserveOnly:
+		return "only observed; the external resource is never created, updated or deleted"
+	default:
+		return fmt.Sprintf("management policies exclude %s", strings.Join(excluded(ov.ManagementPolicies), ", "))
+	}
+}
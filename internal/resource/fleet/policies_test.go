@@ -0,0 +1,134 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+func TestOverrides(t *testing.T) {
+	withPolicies := func(u *unstructured.Unstructured, policies ...any) *unstructured.Unstructured {
+		u.Object["spec"] = map[string]any{"managementPolicies": policies}
+		return u
+	}
+	paused := func(u *unstructured.Unstructured) *unstructured.Unstructured {
+		u.SetAnnotations(map[string]string{"crossplane.io/paused": "true"})
+		return u
+	}
+	ref := func(apiVersion, kind, name string) object.Reference {
+		return object.Reference{APIVersion: apiVersion, Kind: kind, Name: name}
+	}
+
+	objs := []*unstructured.Unstructured{
+		crdOf("s3.aws.upbound.io", "Bucket", "buckets", CategoryManaged, metav1.OwnerReference{Kind: "ProviderRevision", Name: "provider-aws-s3-abc"}),
+		crdOf("example.org", "XDatabase", "xdatabases", CategoryComposite, metav1.OwnerReference{Kind: "CompositeResourceDefinition", Name: "xdatabases.example.org"}),
+		resource("s3.aws.upbound.io/v1beta1", "Bucket", "default"),
+		withPolicies(resource("s3.aws.upbound.io/v1beta1", "Bucket", "all"), "*"),
+		withPolicies(resource("s3.aws.upbound.io/v1beta1", "Bucket", "explicit"), "Observe", "Create", "Update", "Delete", "LateInitialize"),
+		withPolicies(resource("s3.aws.upbound.io/v1beta1", "Bucket", "imported"), "Observe"),
+		withPolicies(resource("s3.aws.upbound.io/v1beta1", "Bucket", "orphaned"), "Observe", "Create", "Update", "LateInitialize"),
+		paused(resource("example.org/v1beta1", "XDatabase", "frozen")),
+	}
+
+	cases := map[string]struct {
+		reason     string
+		categories []string
+		want       *Overrides
+	}{
+		"AllCategories": {
+			reason:     "Paused resources and managed resources with non-default management policies should be returned.",
+			categories: Categories(),
+			want: &Overrides{
+				Kinds:       2,
+				Scanned:     6,
+				Paused:      1,
+				ObserveOnly: 1,
+				Limited:     1,
+				Resources: []Override{
+					{
+						Reference: ref("s3.aws.upbound.io/v1beta1", "Bucket", "imported"), Category: CategoryManaged, Owner: "provider-aws-s3-abc",
+						ObserveOnly: true, ManagementPolicies: []string{"Observe"},
+						Effect: "only observed; the external resource is never created, updated or deleted",
+					},
+					{
+						Reference: ref("s3.aws.upbound.io/v1beta1", "Bucket", "orphaned"), Category: CategoryManaged, Owner: "provider-aws-s3-abc",
+						ManagementPolicies: []string{"Observe", "Create", "Update", "LateInitialize"},
+						Effect:             "management policies exclude Delete",
+					},
+					{
+						Reference: ref("example.org/v1beta1", "XDatabase", "frozen"), Category: CategoryComposite, Owner: "xdatabases.example.org",
+						Paused: true,
+						Effect: "not reconciled until the crossplane.io/paused annotation is removed",
+					},
+				},
+				Errors: []string{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := New(objectfake.NewClient(nil, objs))
+
+			got, err := f.Overrides(context.Background(), tc.categories...)
+			if err != nil {
+				t.Fatalf("\n%s\nOverrides(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nOverrides(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOverridesLarge(t *testing.T) {
+	crd := crdOf("s3.aws.upbound.io", "Bucket", "buckets", CategoryManaged, metav1.OwnerReference{Kind: "ProviderRevision", Name: "provider-aws-s3-abc"})
+	buckets := make([]unstructured.Unstructured, 0, 150)
+	for i := range 150 {
+		u := resource("s3.aws.upbound.io/v1beta1", "Bucket", fmt.Sprintf("bucket-%03d", i))
+		u.Object["spec"] = map[string]any{"managementPolicies": []any{"Observe"}}
+		buckets = append(buckets, *u)
+	}
+
+	kinds := append(objectfake.KindsOf([]*unstructured.Unstructured{crd, &buckets[0]}), objectfake.Kind{GVK: xpkg.RevisionGVK(xpkg.KindProvider)})
+	limits := []int64{}
+	dyn := paged{Interface: objectfake.Dynamic(kinds, []*unstructured.Unstructured{crd}), buckets: buckets, limits: &limits}
+	f := New(object.New(fake.NewClientset(), dyn, objectfake.Mapper(kinds...)))
+
+	got, err := f.Overrides(context.Background(), CategoryManaged)
+	if err != nil {
+		t.Fatalf("Overrides(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(150, got.ObserveOnly); diff != "" {
+		t.Errorf("Overrides(...): every page of resources should be counted: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(150, len(got.Resources)); diff != "" {
+		t.Errorf("Overrides(...): every resource should be returned for the caller to page through: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,543 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package graph provides tool helpers for building the graph of resources a
+Crossplane resource is connected to.
+*/
+package graph
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/deletion"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// Types of edges between resources.
+	EdgeOwns           = "Owns"
+	EdgeComposes       = "Composes"
+	EdgeBinds          = "Binds"
+	EdgeProviderConfig = "UsesProviderConfig"
+	EdgeReferences     = "References"
+	EdgeUses           = "Uses"
+	EdgeProtects       = "Protects"
+
+	// maxNodes of a graph to return to the caller.
+	maxNodes = 50
+
+	// kindProviderConfig is the default kind of a managed resource's
+	// providerConfigRef.
+	kindProviderConfig = "ProviderConfig"
+)
+
+// Graph is the graph of resources a resource is connected to.
+type Graph struct {
+	// Root is the ID of the resource the graph was built from.
+	Root  string `json:"root"`
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+	// Truncated is set if the graph reached the maximum number of nodes.
+	Truncated bool `json:"truncated"`
+	// Format of the diagram, either dot or mermaid.
+	Format  string `json:"format"`
+	Diagram string `json:"diagram"`
+	// Notes about references that couldn't be followed.
+	Notes []string `json:"notes"`
+}
+
+// Node is a resource in a graph.
+type Node struct {
+	ID string `json:"id"`
+	object.Reference
+	Ready  string `json:"ready,omitempty"`
+	Synced string `json:"synced,omitempty"`
+	// Missing is set if the resource is referenced but doesn't exist.
+	Missing bool `json:"missing"`
+}
+
+// Edge connects two resources in a graph.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+	// Field the connection was found at, or the Usage that records it.
+	Field string `json:"field,omitempty"`
+}
+
+// Builder builds the graphs of resources in the configured controlplane.
+type Builder struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Builder.
+type Option func(*Builder)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(b *Builder) {
+		b.log = log
+	}
+}
+
+// New constructs a new Builder.
+func New(obj *object.Client, opts ...Option) *Builder {
+	b := &Builder{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(b)
+	}
+
+	return b
+}
+
+// walk is the state of building a single graph.
+type walk struct {
+	*Builder
+	g     *Graph
+	nodes map[string]*Node
+	edges map[string]*Edge
+	queue []*unstructured.Unstructured
+	crds  []*extv1.CustomResourceDefinition
+}
+
+// Build the graph of the resources the supplied resource is connected to by
+// owner references, composed resource references, claim binding,
+// providerConfigRef, cross-resource references and Usages, rendered as a
+// diagram in the supplied format.
+func (b *Builder) Build(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName, format string) (*Graph, error) {
+	if !slices.Contains(Formats(), format) {
+		return nil, errors.Errorf("unknown format %q, must be one of %s", format, strings.Join(Formats(), ", "))
+	}
+	u, err := b.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &walk{
+		Builder: b,
+		g:       &Graph{Nodes: []Node{}, Edges: []Edge{}, Format: format, Notes: []string{}},
+		nodes:   map[string]*Node{},
+		edges:   map[string]*Edge{},
+	}
+	w.g.Root = w.node(u, true)
+	for len(w.queue) > 0 {
+		next := w.queue[0]
+		w.queue = w.queue[1:]
+		w.expand(ctx, next)
+	}
+	if err := w.usages(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, n := range w.nodes {
+		w.g.Nodes = append(w.g.Nodes, *n)
+	}
+	slices.SortFunc(w.g.Nodes, func(a, b Node) int { return cmp.Compare(a.ID, b.ID) })
+	for _, e := range w.edges {
+		w.g.Edges = append(w.g.Edges, *e)
+	}
+	slices.SortFunc(w.g.Edges, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To), cmp.Compare(a.Type, b.Type))
+	})
+
+	if format == FormatDOT {
+		w.g.Diagram = DOT(w.g)
+	} else {
+		w.g.Diagram = Mermaid(w.g)
+	}
+	return w.g, nil
+}
+
+// ID returns the ID of the referenced resource in a graph.
+func ID(ref object.Reference) string {
+	kind := ref.Kind
+	if g := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).Group; g != "" {
+		kind += "." + g
+	}
+	if ref.Namespace != "" {
+		return kind + "/" + ref.Namespace + "/" + ref.Name
+	}
+	return kind + "/" + ref.Name
+}
+
+// node adds the supplied resource to the graph and returns its ID. Expanded
+// resources are queued so the resources they're connected to are added too.
+func (w *walk) node(u *unstructured.Unstructured, expand bool) string {
+	s := object.Summarize(u)
+	n := &Node{Reference: object.ReferenceTo(u)}
+	n.ID = ID(n.Reference)
+	if c, ok := object.FindCondition(s.Conditions, "Ready"); ok {
+		n.Ready = c.Status
+	}
+	if c, ok := object.FindCondition(s.Conditions, "Synced"); ok {
+		n.Synced = c.Status
+	}
+	w.nodes[n.ID] = n
+	if expand {
+		w.queue = append(w.queue, u)
+	}
+	return n.ID
+}
+
+// ref adds the referenced resource to the graph, unless it's already in it,
+// and returns its ID. It returns an empty ID if the graph is full.
+func (w *walk) ref(ctx context.Context, ref object.Reference, expand bool) string {
+	id := ID(ref)
+	if _, ok := w.nodes[id]; ok {
+		return id
+	}
+	if len(w.nodes) >= maxNodes {
+		w.g.Truncated = true
+		return ""
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		w.g.Notes = append(w.g.Notes, fmt.Sprintf("invalid apiVersion %q of %s %s", ref.APIVersion, ref.Kind, ref.Name))
+		return ""
+	}
+	u, err := w.obj.Get(ctx, gv.WithKind(ref.Kind), types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name})
+	switch {
+	case kerrors.IsNotFound(err) || meta.IsNoMatchError(err):
+		w.nodes[id] = &Node{ID: id, Reference: ref, Missing: true}
+		return id
+	case err != nil:
+		w.g.Notes = append(w.g.Notes, fmt.Sprintf("cannot get %s %s: %s", ref.Kind, ref.Name, err))
+		return ""
+	}
+	return w.node(u, expand)
+}
+
+// edge connects two resources in the graph. An edge found from owner
+// references is dropped in favour of a more specific edge between the same
+// resources, such as the one from a composite resource to a composed
+// resource it owns.
+func (w *walk) edge(from, to, typ, field string) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	owns := from + "|" + to + "|" + EdgeOwns
+	if typ == EdgeOwns {
+		for _, e := range w.edges {
+			if e.From == from && e.To == to {
+				return
+			}
+		}
+	}
+	delete(w.edges, owns)
+	if key := from + "|" + to + "|" + typ; w.edges[key] == nil {
+		w.edges[key] = &Edge{From: from, To: to, Type: typ, Field: field}
+	}
+}
+
+// expand adds the resources the supplied resource is connected to.
+func (w *walk) expand(ctx context.Context, u *unstructured.Unstructured) {
+	id := ID(object.ReferenceTo(u))
+
+	for _, o := range u.GetOwnerReferences() {
+		ref := object.Reference{APIVersion: o.APIVersion, Kind: o.Kind, Name: o.Name, Namespace: w.namespace(o.APIVersion, o.Kind, "", u.GetNamespace())}
+		w.edge(w.ref(ctx, ref, true), id, EdgeOwns, "metadata.ownerReferences")
+	}
+
+	for _, ref := range refs(u, "resourceRefs") {
+		ref.Namespace = w.namespace(ref.APIVersion, ref.Kind, ref.Namespace, u.GetNamespace())
+		w.edge(id, w.ref(ctx, ref, true), EdgeComposes, "resourceRefs")
+	}
+	for _, ref := range refs(u, "resourceRef") {
+		ref.Namespace = w.namespace(ref.APIVersion, ref.Kind, ref.Namespace, u.GetNamespace())
+		w.edge(id, w.ref(ctx, ref, true), EdgeBinds, "resourceRef")
+	}
+	for _, ref := range refs(u, "claimRef") {
+		w.edge(w.ref(ctx, ref, true), id, EdgeBinds, "claimRef")
+	}
+
+	w.providerConfig(ctx, u, id)
+	for _, p := range []string{"forProvider", "initProvider"} {
+		v, err := fieldpath.Pave(u.Object).GetValue("spec." + p)
+		if err != nil {
+			continue
+		}
+		w.references(ctx, u, id, "spec."+p, v)
+	}
+}
+
+// refs returns the references in the supplied Crossplane machinery field,
+// which may hold a single reference or a list of them.
+func refs(u *unstructured.Unstructured, field string) []object.Reference {
+	v, ok := composition.XRField(u, field)
+	if !ok {
+		return nil
+	}
+	if _, single := v.(map[string]any); single {
+		v = []any{v}
+	}
+	var out []object.Reference
+	_ = fieldpath.Pave(map[string]any{"refs": v}).GetValueInto("refs", &out)
+	return slices.DeleteFunc(out, func(r object.Reference) bool { return r.Kind == "" || r.Name == "" })
+}
+
+// namespace returns the namespace of a referenced resource. References to
+// namespaced kinds default to the supplied namespace of the referencing
+// resource, while references to cluster scoped kinds have none.
+func (w *walk) namespace(apiVersion, kind, ns, def string) string {
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	m, err := w.obj.Mapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil || m.Scope.Name() != meta.RESTScopeNameNamespace {
+		return ""
+	}
+	return cmp.Or(ns, def)
+}
+
+// providerConfig adds the provider config of the supplied managed resource.
+// Provider configs are served by the managed resource's group or one of its
+// parent groups, e.g. aws.upbound.io for s3.aws.upbound.io.
+func (w *walk) providerConfig(ctx context.Context, u *unstructured.Unstructured, id string) {
+	pv := fieldpath.Pave(u.Object)
+	name, _ := pv.GetString("spec.providerConfigRef.name")
+	if name == "" {
+		return
+	}
+	kind, _ := pv.GetString("spec.providerConfigRef.kind")
+	kind = cmp.Or(kind, kindProviderConfig)
+
+	for g := u.GroupVersionKind().Group; strings.Contains(g, "."); g = g[strings.Index(g, ".")+1:] {
+		m, err := w.obj.Mapper().RESTMapping(schema.GroupKind{Group: g, Kind: kind})
+		if err != nil {
+			continue
+		}
+		ref := object.Reference{APIVersion: m.GroupVersionKind.GroupVersion().String(), Kind: kind, Name: name}
+		if m.Scope.Name() == meta.RESTScopeNameNamespace {
+			ref.Namespace = u.GetNamespace()
+		}
+		w.edge(id, w.ref(ctx, ref, false), EdgeProviderConfig, "spec.providerConfigRef")
+		return
+	}
+	w.g.Notes = append(w.g.Notes, fmt.Sprintf("no %s kind is served for the providerConfigRef of %s %s", kind, u.GetKind(), u.GetName()))
+}
+
+// references adds the resources referenced by the *Ref, *Refs, *Selector
+// and *Selectors fields found in the supplied value of a managed resource.
+func (w *walk) references(ctx context.Context, u *unstructured.Unstructured, id, path string, v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			p := path + "." + k
+			switch {
+			case strings.HasSuffix(strings.ToLower(k), "secretref"):
+				// Secrets aren't part of the graph, and reading them isn't
+				// allowed.
+				continue
+			case strings.HasSuffix(k, "Ref") || strings.HasSuffix(k, "Refs"):
+				w.referenced(ctx, u, id, p, k, t[k])
+			case strings.HasSuffix(k, "Selector") || strings.HasSuffix(k, "Selectors"):
+				w.selected(ctx, u, id, p, k, t[k])
+			default:
+				w.references(ctx, u, id, p, t[k])
+			}
+		}
+	case []any:
+		for i, e := range t {
+			w.references(ctx, u, id, fmt.Sprintf("%s[%d]", path, i), e)
+		}
+	}
+}
+
+// referenced adds the resources referenced by name by the supplied *Ref or
+// *Refs field.
+func (w *walk) referenced(ctx context.Context, u *unstructured.Unstructured, id, path, field string, v any) {
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+	gvk, found := w.referencedKind(ctx, u.GroupVersionKind().Group, field)
+	for _, i := range items {
+		m, _ := i.(map[string]any)
+		name, _ := m["name"].(string)
+		if name == "" {
+			continue
+		}
+		if !found {
+			w.g.Notes = append(w.g.Notes, fmt.Sprintf("cannot determine the kind %s of %s %s references as %s", path, u.GetKind(), u.GetName(), name))
+			continue
+		}
+		ns, _ := m["namespace"].(string)
+		ref := object.Reference{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Name: name}
+		ref.Namespace = w.namespace(ref.APIVersion, ref.Kind, ns, u.GetNamespace())
+		w.edge(id, w.ref(ctx, ref, true), EdgeReferences, path)
+	}
+}
+
+// selected adds the resources matched by the supplied *Selector or
+// *Selectors field.
+func (w *walk) selected(ctx context.Context, u *unstructured.Unstructured, id, path, field string, v any) {
+	m, _ := v.(map[string]any)
+	if m == nil {
+		return
+	}
+	gvk, found := w.referencedKind(ctx, u.GroupVersionKind().Group, field)
+	if !found {
+		w.g.Notes = append(w.g.Notes, fmt.Sprintf("cannot determine the kind %s of %s %s selects", path, u.GetKind(), u.GetName()))
+		return
+	}
+	ml, _, _ := unstructured.NestedStringMap(m, "matchLabels")
+	controller, _, _ := unstructured.NestedBool(m, "matchControllerRef")
+	items, err := w.obj.List(ctx, gvk, w.namespace(gvk.GroupVersion().String(), gvk.Kind, "", u.GetNamespace()), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(ml).String(),
+	})
+	if err != nil {
+		w.g.Notes = append(w.g.Notes, fmt.Sprintf("cannot list the %s resources %s selects: %s", gvk.Kind, path, err))
+		return
+	}
+	owner := metav1.GetControllerOf(u)
+	for i := range items {
+		if c := metav1.GetControllerOf(&items[i]); controller && (owner == nil || c == nil || c.UID != owner.UID) {
+			continue
+		}
+		w.edge(id, w.ref(ctx, object.ReferenceTo(&items[i]), true), EdgeReferences, path)
+	}
+}
+
+// referencedKind returns the kind a *Ref, *Refs, *Selector or *Selectors
+// field refers to. Crossplane references don't record their kind, so it's
+// the longest kind of the same provider family that ends the field name once
+// the suffix and any Id, Arn or Name suffix is removed, e.g. VPC for
+// vpcIdRef.
+func (w *walk) referencedKind(ctx context.Context, group, field string) (schema.GroupVersionKind, bool) {
+	base := field
+	for _, s := range []string{"Refs", "Ref", "Selectors", "Selector"} {
+		if b, ok := strings.CutSuffix(base, s); ok {
+			base = b
+			break
+		}
+	}
+	for _, s := range []string{"Id", "ID", "Arn", "Name"} {
+		if b, ok := strings.CutSuffix(base, s); ok && b != "" {
+			base = b
+			break
+		}
+	}
+	base = strings.ToLower(base)
+
+	if w.crds == nil {
+		crds, err := crd.List(ctx, w.obj)
+		if err != nil {
+			w.log.Debug("cannot list CustomResourceDefinitions", "error", err)
+		}
+		w.crds = append([]*extv1.CustomResourceDefinition{}, crds...)
+	}
+
+	family := group
+	if parts := strings.Split(group, "."); len(parts) > 2 {
+		family = strings.Join(parts[1:], ".")
+	}
+	var best *extv1.CustomResourceDefinition
+	for _, c := range w.crds {
+		if c.Spec.Group != family && !strings.HasSuffix(c.Spec.Group, "."+family) {
+			continue
+		}
+		if !strings.HasSuffix(base, strings.ToLower(c.Spec.Names.Kind)) {
+			continue
+		}
+		if best == nil || len(c.Spec.Names.Kind) > len(best.Spec.Names.Kind) ||
+			(len(c.Spec.Names.Kind) == len(best.Spec.Names.Kind) && c.Spec.Group == group) {
+			best = c
+		}
+	}
+	if best == nil {
+		return schema.GroupVersionKind{}, false
+	}
+	v, err := crd.Version(best, "")
+	if err != nil {
+		return schema.GroupVersionKind{}, false
+	}
+	return schema.GroupVersionKind{Group: best.Spec.Group, Version: v.Name, Kind: best.Spec.Names.Kind}, true
+}
+
+// usages adds the Usages of the resources in the graph. A Usage by another
+// resource connects that resource to the used one, while a Usage without one
+// protects the used resource itself. Usage kinds that aren't served are
+// skipped.
+func (w *walk) usages(ctx context.Context) error {
+	for _, gk := range deletion.UsageKinds() {
+		m, err := w.obj.Mapper().RESTMapping(gk)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "cannot find %s kind", gk)
+		}
+		l, err := w.obj.List(ctx, m.GroupVersionKind, "", metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for i := range l {
+			w.usage(ctx, &l[i])
+		}
+	}
+	return nil
+}
+
+// usage adds the supplied Usage if it involves a resource in the graph.
+func (w *walk) usage(ctx context.Context, usage *unstructured.Unstructured) {
+	refs := deletion.ParseUsage(usage)
+	if refs.Of.Name == "" {
+		return
+	}
+	_, ofIn := w.nodes[ID(refs.Of)]
+
+	switch {
+	case refs.By != nil && refs.By.Name != "":
+		if _, byIn := w.nodes[ID(*refs.By)]; ofIn || byIn {
+			w.edge(w.ref(ctx, *refs.By, false), w.ref(ctx, refs.Of, false), EdgeUses, fmt.Sprintf("%s %s", usage.GetKind(), usage.GetName()))
+		}
+	case ofIn:
+		w.edge(w.ref(ctx, object.ReferenceTo(usage), false), ID(refs.Of), EdgeProtects, "spec.of")
+	}
+}
+
+// sortedKeys returns the sorted keys of the supplied map.
+func sortedKeys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,238 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var policyGVK = schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta1", Kind: "BucketPolicy"}
+
+func customResourceDefinition(plural string, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": plural + "." + gvk.Group},
+		"spec": map[string]any{
+			"group":    gvk.Group,
+			"names":    map[string]any{"kind": gvk.Kind, "plural": plural},
+			"versions": []any{map[string]any{"name": gvk.Version, "served": true, "storage": true}},
+		},
+	}}
+}
+
+func composed(kind, name string, ready string, forProvider map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":            name,
+			"ownerReferences": []any{map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "XDatabase", "name": "my-db-x", "uid": "xr-uid", "controller": true}},
+		},
+		"spec": map[string]any{
+			"forProvider":       forProvider,
+			"providerConfigRef": map[string]any{"name": "default"},
+		},
+		"status": map[string]any{"conditions": []any{map[string]any{"type": "Ready", "status": ready}}},
+	}}
+}
+
+func objects() []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{
+		customResourceDefinition("buckets", schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta1", Kind: "Bucket"}),
+		customResourceDefinition("bucketpolicies", policyGVK),
+		customResourceDefinition("roles", schema.GroupVersionKind{Group: "iam.aws.upbound.io", Version: "v1beta1", Kind: "Role"}),
+		{Object: map[string]any{
+			"apiVersion": "example.org/v1alpha1",
+			"kind":       "Database",
+			"metadata":   map[string]any{"namespace": "default", "name": "my-db"},
+			"spec":       map[string]any{"resourceRef": map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "XDatabase", "name": "my-db-x"}},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "example.org/v1alpha1",
+			"kind":       "XDatabase",
+			"metadata":   map[string]any{"name": "my-db-x"},
+			"spec": map[string]any{
+				"claimRef": map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "Database", "namespace": "default", "name": "my-db"},
+				"resourceRefs": []any{
+					map[string]any{"apiVersion": "s3.aws.upbound.io/v1beta1", "kind": "Bucket", "name": "my-bucket"},
+					map[string]any{"apiVersion": "s3.aws.upbound.io/v1beta1", "kind": "BucketPolicy", "name": "my-policy"},
+				},
+			},
+		}},
+		composed("Bucket", "my-bucket", "True", map[string]any{"region": "us-east-1"}),
+		composed("BucketPolicy", "my-policy", "False", map[string]any{
+			"bucketRef":         map[string]any{"name": "my-bucket"},
+			"roleArnRef":        map[string]any{"name": "my-role"},
+			"passwordSecretRef": map[string]any{"name": "secret", "namespace": "default", "key": "password"},
+			"widgetRef":         map[string]any{"name": "my-widget"},
+		}),
+		{Object: map[string]any{
+			"apiVersion": "aws.upbound.io/v1beta1",
+			"kind":       "ProviderConfig",
+			"metadata":   map[string]any{"name": "default"},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "apiextensions.crossplane.io/v1alpha1",
+			"kind":       "Usage",
+			"metadata":   map[string]any{"name": "policy-uses-bucket"},
+			"spec": map[string]any{
+				"of": map[string]any{"apiVersion": "s3.aws.upbound.io/v1beta1", "kind": "Bucket", "resourceRef": map[string]any{"name": "my-bucket"}},
+				"by": map[string]any{"apiVersion": "s3.aws.upbound.io/v1beta1", "kind": "BucketPolicy", "resourceRef": map[string]any{"name": "my-policy"}},
+			},
+		}},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	const (
+		claim    = "Database.example.org/default/my-db"
+		xr       = "XDatabase.example.org/my-db-x"
+		bucket   = "Bucket.s3.aws.upbound.io/my-bucket"
+		policy   = "BucketPolicy.s3.aws.upbound.io/my-policy"
+		pc       = "ProviderConfig.aws.upbound.io/default"
+		role     = "Role.iam.aws.upbound.io/my-role"
+		resource = "s3.aws.upbound.io/v1beta1"
+	)
+	kinds := []objectfake.Kind{{GVK: schema.GroupVersionKind{Group: "iam.aws.upbound.io", Version: "v1beta1", Kind: "Role"}}}
+
+	type want struct {
+		graph *Graph
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		gvk    schema.GroupVersionKind
+		nn     types.NamespacedName
+		format string
+		want   want
+	}{
+		"ManagedResource": {
+			reason: "The graph of a composed resource should connect it to its composite resource, claim, siblings, provider config, references and Usages.",
+			gvk:    policyGVK,
+			nn:     types.NamespacedName{Name: "my-policy"},
+			format: FormatMermaid,
+			want: want{graph: &Graph{
+				Root: policy,
+				Nodes: []Node{
+					{ID: bucket, Reference: object.Reference{APIVersion: resource, Kind: "Bucket", Name: "my-bucket"}, Ready: "True"},
+					{ID: policy, Reference: object.Reference{APIVersion: resource, Kind: "BucketPolicy", Name: "my-policy"}, Ready: "False"},
+					{ID: claim, Reference: object.Reference{APIVersion: "example.org/v1alpha1", Kind: "Database", Namespace: "default", Name: "my-db"}},
+					{ID: pc, Reference: object.Reference{APIVersion: "aws.upbound.io/v1beta1", Kind: "ProviderConfig", Name: "default"}},
+					{ID: role, Reference: object.Reference{APIVersion: "iam.aws.upbound.io/v1beta1", Kind: "Role", Name: "my-role"}, Missing: true},
+					{ID: xr, Reference: object.Reference{APIVersion: "example.org/v1alpha1", Kind: "XDatabase", Name: "my-db-x"}},
+				},
+				Edges: []Edge{
+					{From: bucket, To: pc, Type: EdgeProviderConfig, Field: "spec.providerConfigRef"},
+					{From: policy, To: bucket, Type: EdgeReferences, Field: "spec.forProvider.bucketRef"},
+					{From: policy, To: bucket, Type: EdgeUses, Field: "Usage policy-uses-bucket"},
+					{From: policy, To: pc, Type: EdgeProviderConfig, Field: "spec.providerConfigRef"},
+					{From: policy, To: role, Type: EdgeReferences, Field: "spec.forProvider.roleArnRef"},
+					{From: claim, To: xr, Type: EdgeBinds, Field: "claimRef"},
+					{From: xr, To: bucket, Type: EdgeComposes, Field: "resourceRefs"},
+					{From: xr, To: policy, Type: EdgeComposes, Field: "resourceRefs"},
+				},
+				Format: FormatMermaid,
+				Notes:  []string{"cannot determine the kind spec.forProvider.widgetRef of BucketPolicy my-policy references as my-widget"},
+			}},
+		},
+		"UnknownFormat": {
+			reason: "An error should be returned for an unknown format.",
+			gvk:    policyGVK,
+			nn:     types.NamespacedName{Name: "my-policy"},
+			format: "svg",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := New(objectfake.NewClient(kinds, objects()))
+
+			got, err := b.Build(context.Background(), tc.gvk, tc.nn, tc.format)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nBuild(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.graph, got, cmpopts.IgnoreFields(Graph{}, "Diagram")); diff != "" {
+				t.Errorf("\n%s\nBuild(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := &Graph{
+		Root: "XDatabase.example.org/my-db-x",
+		Nodes: []Node{
+			{ID: "Bucket.s3.aws.upbound.io/my-bucket", Reference: object.Reference{Kind: "Bucket", Name: "my-bucket"}, Ready: "False"},
+			{ID: "XDatabase.example.org/my-db-x", Reference: object.Reference{Kind: "XDatabase", Name: "my-db-x"}},
+		},
+		Edges: []Edge{{From: "XDatabase.example.org/my-db-x", To: "Bucket.s3.aws.upbound.io/my-bucket", Type: EdgeComposes}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		render func(*Graph) string
+		want   string
+	}{
+		"DOT": {
+			reason: "Nodes should be labelled with their kind and name, with unhealthy nodes in red and the root in bold.",
+			render: DOT,
+			want: `digraph {
+  rankdir=LR;
+  node [shape=box];
+  "Bucket.s3.aws.upbound.io/my-bucket" [label="Bucket\nmy-bucket", color=red];
+  "XDatabase.example.org/my-db-x" [label="XDatabase\nmy-db-x", penwidth=2];
+  "XDatabase.example.org/my-db-x" -> "Bucket.s3.aws.upbound.io/my-bucket" [label="Composes"];
+}
+`,
+		},
+		"Mermaid": {
+			reason: "Nodes should be labelled with their kind and name, with unhealthy nodes in red and the root in bold.",
+			render: Mermaid,
+			want: `flowchart LR
+  n0["Bucket<br/>my-bucket"]
+  n1["XDatabase<br/>my-db-x"]
+  n1 -->|Composes| n0
+  classDef unhealthy stroke:#d33,color:#d33
+  classDef root stroke-width:3px
+  class n0 unhealthy
+  class n1 root
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.render(g)); diff != "" {
+				t.Errorf("\n%s\n%s(...): -want, +got:\n%s", tc.reason, name, diff)
+			}
+		})
+	}
+}
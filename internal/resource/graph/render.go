@@ -0,0 +1,104 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FormatDOT renders a graph as Graphviz DOT.
+	FormatDOT = "dot"
+	// FormatMermaid renders a graph as a Mermaid flowchart.
+	FormatMermaid = "mermaid"
+)
+
+// Formats returns the formats a graph can be rendered in.
+func Formats() []string {
+	return []string{FormatMermaid, FormatDOT}
+}
+
+// unhealthy returns true if the supplied node is missing, not Ready or not
+// Synced.
+func unhealthy(n Node) bool {
+	return n.Missing || n.Ready == "False" || n.Synced == "False"
+}
+
+// label returns the label of the supplied node.
+func label(n Node) string {
+	name := n.Name
+	if n.Namespace != "" {
+		name = n.Namespace + "/" + n.Name
+	}
+	if n.Missing {
+		name += " (missing)"
+	}
+	return n.Kind + "\n" + name
+}
+
+// DOT renders the supplied graph as Graphviz DOT. Unhealthy resources are
+// red and the root resource is bold.
+func DOT(g *Graph) string {
+	b := &strings.Builder{}
+	b.WriteString("digraph {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + strconv.Quote(label(n))}
+		if unhealthy(n) {
+			attrs = append(attrs, "color=red")
+		}
+		if n.Missing {
+			attrs = append(attrs, "style=dashed")
+		}
+		if n.ID == g.Root {
+			attrs = append(attrs, "penwidth=2")
+		}
+		fmt.Fprintf(b, "  %s [%s];\n", strconv.Quote(n.ID), strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(b, "  %s -> %s [label=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(e.Type))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the supplied graph as a Mermaid flowchart. Unhealthy
+// resources are red and the root resource is bold.
+func Mermaid(g *Graph) string {
+	ids := map[string]string{}
+	b := &strings.Builder{}
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		text := strings.ReplaceAll(strings.ReplaceAll(label(n), `"`, "#quot;"), "\n", "<br/>")
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", ids[n.ID], text)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(b, "  %s -->|%s| %s\n", ids[e.From], e.Type, ids[e.To])
+	}
+	b.WriteString("  classDef unhealthy stroke:#d33,color:#d33\n  classDef root stroke-width:3px\n")
+	for _, n := range g.Nodes {
+		if unhealthy(n) {
+			fmt.Fprintf(b, "  class %s unhealthy\n", ids[n.ID])
+		}
+	}
+	if id, ok := ids[g.Root]; ok {
+		fmt.Fprintf(b, "  class %s root\n", id)
+	}
+	return b.String()
+}
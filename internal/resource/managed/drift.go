@@ -0,0 +1,302 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package managed
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// FieldDiffers is the state of a desired field whose observed value
+	// differs.
+	FieldDiffers = "Differs"
+	// FieldUnobserved is the state of a desired field the provider doesn't
+	// report, such as a write-only or sensitive field.
+	FieldUnobserved = "Unobserved"
+	// FieldInitOnly is the state of an initProvider field, which is only
+	// applied when the external resource is created and never reconciled.
+	FieldInitOnly = "InitOnly"
+
+	// conditionSynced is the condition managed resources use to report
+	// whether they are in sync with their external resource.
+	conditionSynced = "Synced"
+
+	// updatedExternalResource is the reason of the event a managed resource
+	// records when the provider updates its external resource.
+	updatedExternalResource = "UpdatedExternalResource"
+	// flappingUpdates is the number of updates of an external resource
+	// after which a managed resource is considered to be flapping.
+	flappingUpdates = 3
+	// flappingSwitches is the number of switches between error and success
+	// events after which a managed resource is considered to be flapping.
+	flappingSwitches = 3
+)
+
+// index matches list indices in a field path.
+var index = regexp.MustCompile(`\[\d+\]`)
+
+// Drift compares the desired and observed state of a managed resource.
+type Drift struct {
+	Resource object.Summary `json:"resource"`
+	Synced   string         `json:"synced,omitempty"`
+	// Fields of spec.forProvider whose observed value in status.atProvider
+	// differs or isn't reported.
+	Fields []FieldDrift `json:"fields"`
+	// InSync is the number of spec.forProvider fields whose observed value
+	// matches.
+	InSync int `json:"inSync"`
+	// InitProvider fields are only applied when the external resource is
+	// created, so differences are expected.
+	InitProvider []FieldDrift `json:"initProvider"`
+	// LateInitialized are the spec.forProvider fields the provider filled in
+	// from the observed state, rather than being set by the user.
+	LateInitialized []string `json:"lateInitialized"`
+	// Flapping is set if events show the provider repeatedly updating the
+	// external resource or alternating between errors and success.
+	Flapping   bool   `json:"flapping"`
+	FlapReason string `json:"flapReason,omitempty"`
+	// EventReasons counts the events of the managed resource by reason.
+	EventReasons map[string]int32 `json:"eventReasons"`
+}
+
+// FieldDrift is a desired field and its observed value.
+type FieldDrift struct {
+	Path     string `json:"path"`
+	Desired  any    `json:"desired,omitempty"`
+	Observed any    `json:"observed,omitempty"`
+	State    string `json:"state"`
+}
+
+// Drift compares the desired state in the supplied managed resource's
+// spec.forProvider with the observed state in its status.atProvider.
+func (r *Resources) Drift(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Drift, error) {
+	mr, err := r.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.obj.Events(ctx, mr)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Drift{
+		Resource:        object.Summarize(mr),
+		Fields:          []FieldDrift{},
+		InitProvider:    []FieldDrift{},
+		LateInitialized: []string{},
+		EventReasons:    map[string]int32{},
+	}
+	if c, ok := object.FindCondition(d.Resource.Conditions, conditionSynced); ok {
+		d.Synced = c.Status
+	}
+
+	desired := leaves("", nested(mr, "spec", "forProvider"))
+	observed := leaves("", nested(mr, "status", "atProvider"))
+	for _, p := range sortedKeys(desired) {
+		o, ok := observed[p]
+		switch {
+		case !ok:
+			d.Fields = append(d.Fields, FieldDrift{Path: p, Desired: desired[p], State: FieldUnobserved})
+		case equal(desired[p], o):
+			d.InSync++
+		default:
+			d.Fields = append(d.Fields, FieldDrift{Path: p, Desired: desired[p], Observed: o, State: FieldDiffers})
+		}
+	}
+
+	initial := leaves("", nested(mr, "spec", "initProvider"))
+	for _, p := range sortedKeys(initial) {
+		d.InitProvider = append(d.InitProvider, FieldDrift{Path: p, Desired: initial[p], Observed: observed[p], State: FieldInitOnly})
+	}
+
+	owned := lateInitialized(mr.GetManagedFields())
+	for _, p := range sortedKeys(desired) {
+		if slices.ContainsFunc(owned, func(o string) bool { return covers(o, index.ReplaceAllString(p, "[*]")) }) {
+			d.LateInitialized = append(d.LateInitialized, p)
+		}
+	}
+
+	d.Flapping, d.FlapReason = flapping(events, d.EventReasons)
+	return d, nil
+}
+
+// nested returns the object at the supplied fields of u.
+func nested(u *unstructured.Unstructured, fields ...string) map[string]any {
+	m, _, _ := unstructured.NestedMap(u.Object, fields...)
+	return m
+}
+
+// leaves returns the leaf values of the supplied value keyed by their field
+// path.
+func leaves(path string, v any) map[string]any {
+	out := map[string]any{}
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			for lp, lv := range leaves(p, e) {
+				out[lp] = lv
+			}
+		}
+	case []any:
+		for i, e := range t {
+			for lp, lv := range leaves(fmt.Sprintf("%s[%d]", path, i), e) {
+				out[lp] = lv
+			}
+		}
+	default:
+		if path != "" {
+			out[path] = v
+		}
+	}
+	return out
+}
+
+// equal compares desired and observed values. Numbers are compared by value
+// regardless of their type.
+func equal(a, b any) bool {
+	af, aok := object.Number(a)
+	bf, bok := object.Number(b)
+	if aok && bok {
+		return af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// lateInitialized returns the spec.forProvider field paths owned by the
+// managers that write the supplied object's status.atProvider, which are the
+// provider's. List elements are represented as [*].
+func lateInitialized(entries []metav1.ManagedFieldsEntry) []string {
+	providers := map[string]bool{}
+	for _, e := range entries {
+		if e.Subresource == "status" && slices.ContainsFunc(fieldPaths(e), func(p string) bool { return strings.HasPrefix(p, "status.atProvider") }) {
+			providers[e.Manager] = true
+		}
+	}
+
+	var out []string
+	for _, e := range entries {
+		if e.Subresource != "" || !providers[e.Manager] {
+			continue
+		}
+		for _, p := range fieldPaths(e) {
+			if rest, ok := strings.CutPrefix(p, "spec.forProvider."); ok {
+				out = append(out, rest)
+			}
+		}
+	}
+	return out
+}
+
+// fieldPaths returns the leaf field paths in the supplied managed fields
+// entry.
+func fieldPaths(e metav1.ManagedFieldsEntry) []string {
+	if e.FieldsV1 == nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(e.FieldsV1.Raw, &fields); err != nil {
+		return nil
+	}
+	return walkFields("", fields)
+}
+
+// walkFields returns the leaf field paths of the supplied fieldsV1 set.
+// Fields are prefixed with f: while list elements are prefixed with k:, v:
+// or i:.
+func walkFields(path string, fields map[string]any) []string {
+	var out []string
+	for k, v := range fields {
+		if k == "." {
+			continue
+		}
+		p := path + "[*]"
+		if name, ok := strings.CutPrefix(k, "f:"); ok {
+			p = name
+			if path != "" {
+				p = path + "." + name
+			}
+		}
+		children, _ := v.(map[string]any)
+		if len(children) == 0 {
+			out = append(out, p)
+			continue
+		}
+		out = append(out, walkFields(p, children)...)
+	}
+	return out
+}
+
+// covers returns true if the supplied owned field path is the supplied
+// field path or one of its parents.
+func covers(owned, path string) bool {
+	return path == owned || strings.HasPrefix(path, owned+".") || strings.HasPrefix(path, owned+"[")
+}
+
+// flapping determines from the supplied events, ordered from oldest to most
+// recent, whether a managed resource is flapping. It counts the events by
+// reason into the supplied map.
+func flapping(events []object.Event, reasons map[string]int32) (bool, string) {
+	switches, last := 0, ""
+	for _, e := range events {
+		reasons[e.Reason] += max(e.Count, 1)
+
+		state := "ok"
+		if e.Type == "Warning" || strings.HasPrefix(e.Reason, "Cannot") {
+			state = "error"
+		}
+		if last != "" && state != last {
+			switches++
+		}
+		last = state
+	}
+
+	if n := reasons[updatedExternalResource]; n >= flappingUpdates {
+		return true, fmt.Sprintf("the provider updated the external resource %d times, which suggests the desired state never converges with the observed state", n)
+	}
+	if switches >= flappingSwitches {
+		return true, fmt.Sprintf("recent events switched between errors and successful reconciles %d times", switches)
+	}
+	return false, ""
+}
+
+// sortedKeys returns the sorted keys of the supplied map.
+func sortedKeys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	slices.SortFunc(out, cmp.Compare[string])
+	return out
+}
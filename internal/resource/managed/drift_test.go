@@ -0,0 +1,160 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package managed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+func driftingBucket() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind":       "Bucket",
+		"metadata":   map[string]any{"name": "my-bucket"},
+		"spec": map[string]any{
+			"forProvider": map[string]any{
+				"region":            "eu-west-1",
+				"objectLockEnabled": false,
+				"tags":              map[string]any{"team": "platform", "env": "prod"},
+				"forceDestroy":      true,
+			},
+			"initProvider": map[string]any{"acl": "private"},
+		},
+		"status": map[string]any{
+			"atProvider": map[string]any{
+				"region":            "eu-west-1",
+				"objectLockEnabled": false,
+				"tags":              map[string]any{"team": "platform", "env": "dev"},
+				"acl":               "public-read",
+			},
+			"conditions": []any{map[string]any{"type": "Synced", "status": "True", "reason": "ReconcileSuccess"}},
+		},
+	}}
+	u.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:  "kubectl",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:forProvider":{"f:region":{},"f:tags":{".":{},"f:team":{},"f:env":{}},"f:forceDestroy":{}}}}`)},
+		},
+		{
+			Manager:  "provider-aws-s3",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:forProvider":{"f:objectLockEnabled":{}}}}`)},
+		},
+		{
+			Manager:     "provider-aws-s3",
+			Subresource: "status",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:atProvider":{"f:region":{}}}}`)},
+		},
+	})
+	return u
+}
+
+func TestDrift(t *testing.T) {
+	event := func(name, typ, reason string, count int32, minute int) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+			InvolvedObject: corev1.ObjectReference{Kind: "Bucket", Name: "my-bucket"},
+			Type:           typ,
+			Reason:         reason,
+			Count:          count,
+			LastTimestamp:  metav1.Date(2025, 1, 1, 0, minute, 0, 0, metav1.Now().Location()),
+		}
+	}
+
+	type want struct {
+		fields          []FieldDrift
+		inSync          int
+		initProvider    []FieldDrift
+		lateInitialized []string
+		flapping        bool
+		reasons         map[string]int32
+	}
+
+	cases := map[string]struct {
+		reason string
+		events []runtime.Object
+		want   want
+	}{
+		"Converging": {
+			reason: "Differing, unobserved, initProvider and late-initialized fields should be reported.",
+			events: []runtime.Object{event("a", "Normal", "UpdatedExternalResource", 1, 1)},
+			want: want{
+				fields: []FieldDrift{
+					{Path: "forceDestroy", Desired: true, State: FieldUnobserved},
+					{Path: "tags.env", Desired: "prod", Observed: "dev", State: FieldDiffers},
+				},
+				inSync:          3,
+				initProvider:    []FieldDrift{{Path: "acl", Desired: "private", Observed: "public-read", State: FieldInitOnly}},
+				lateInitialized: []string{"objectLockEnabled"},
+				reasons:         map[string]int32{"UpdatedExternalResource": 1},
+			},
+		},
+		"Flapping": {
+			reason: "A resource the provider keeps updating should be flagged as flapping.",
+			events: []runtime.Object{
+				event("a", "Normal", "UpdatedExternalResource", 5, 1),
+				event("b", "Warning", "CannotUpdateExternalResource", 1, 2),
+			},
+			want: want{
+				fields: []FieldDrift{
+					{Path: "forceDestroy", Desired: true, State: FieldUnobserved},
+					{Path: "tags.env", Desired: "prod", Observed: "dev", State: FieldDiffers},
+				},
+				inSync:          3,
+				initProvider:    []FieldDrift{{Path: "acl", Desired: "private", Observed: "public-read", State: FieldInitOnly}},
+				lateInitialized: []string{"objectLockEnabled"},
+				flapping:        true,
+				reasons:         map[string]int32{"UpdatedExternalResource": 5, "CannotUpdateExternalResource": 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := objectfake.NewClient(nil, []*unstructured.Unstructured{driftingBucket()}, tc.events...)
+			r := New(obj, xpkg.New(obj, pod.New(obj.Clientset())))
+
+			d, err := r.Drift(context.Background(), bucketGVK, types.NamespacedName{Name: "my-bucket"})
+			if err != nil {
+				t.Fatalf("\n%s\nDrift(...): %v", tc.reason, err)
+			}
+			got := want{
+				fields:          d.Fields,
+				inSync:          d.InSync,
+				initProvider:    d.InitProvider,
+				lateInitialized: d.LateInitialized,
+				flapping:        d.Flapping,
+				reasons:         d.EventReasons,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nDrift(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package managed provides tool helpers for working with Crossplane managed
+resources and the providers that reconcile them.
+*/
+package managed
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+// kindProviderRevision is the kind of the revisions that install a
+// provider's CRDs.
+const kindProviderRevision = "ProviderRevision"
+
+// Provider identifies the provider that reconciles a managed resource.
+type Provider struct {
+	Name     string `json:"name"`
+	Revision string `json:"revision"`
+	CRD      string `json:"crd"`
+}
+
+// Logs are the provider logs that mention a managed resource.
+type Logs struct {
+	Resource     object.Summary `json:"resource"`
+	ExternalName string         `json:"externalName,omitempty"`
+	Provider     Provider       `json:"provider"`
+	// Terms a log line must contain at least one of to be included.
+	Terms []string  `json:"terms"`
+	Pods  []PodLogs `json:"pods"`
+}
+
+// PodLogs are the matching log lines of a provider pod.
+type PodLogs struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	// Scanned is the number of recent log lines searched.
+	Scanned int      `json:"scanned"`
+	Lines   []string `json:"lines"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Resources provides methods for deriving details for managed resources in
+// the configured controlplane.
+type Resources struct {
+	log logging.Logger
+	obj *object.Client
+	pkg *xpkg.Packages
+}
+
+// Option modifies the underlying Resources.
+type Option func(*Resources)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(r *Resources) {
+		r.log = log
+	}
+}
+
+// New constructs a new Resources.
+func New(obj *object.Client, pkg *xpkg.Packages, opts ...Option) *Resources {
+	r := &Resources{
+		log: logging.NewNopLogger(),
+		obj: obj,
+		pkg: pkg,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// Provider returns the provider that reconciles managed resources of the
+// supplied kind, by following the owner of the kind's CRD to the provider's
+// active revision.
+func (r *Resources) Provider(ctx context.Context, gvk schema.GroupVersionKind) (*Provider, error) {
+	c, err := crd.ForKind(ctx, r.obj, gvk)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(c.GetOwnerReferences(), func(ref metav1.OwnerReference) bool { return ref.Kind == kindProviderRevision })
+	if i < 0 {
+		return nil, errors.Errorf("CustomResourceDefinition %s was not installed by a Provider", c.GetName())
+	}
+	owner := c.GetOwnerReferences()[i].Name
+
+	rev, err := r.obj.Get(ctx, xpkg.RevisionGVK(xpkg.KindProvider), types.NamespacedName{Name: owner})
+	if err != nil {
+		return nil, err
+	}
+	name := rev.GetLabels()[xpkg.LabelPackage]
+	if name == "" {
+		return nil, errors.Errorf("%s %s has no %s label", kindProviderRevision, owner, xpkg.LabelPackage)
+	}
+	active, err := r.pkg.ActiveRevision(ctx, xpkg.KindProvider, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{Name: name, Revision: active, CRD: c.GetName()}, nil
+}
+
+// Logs returns the log lines of the pods running the supplied managed
+// resource's provider that mention its name, UID or external name. Only the
+// supplied number of most recent lines of each pod are searched.
+func (r *Resources) Logs(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName, tailLines int64) (*Logs, error) {
+	mr, err := r.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := r.Provider(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Logs{
+		Resource:     object.Summarize(mr),
+		ExternalName: meta.GetExternalName(mr),
+		Provider:     *prov,
+		Terms:        Terms(mr),
+		Pods:         []PodLogs{},
+	}
+
+	pods, err := r.obj.Clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{xpkg.LabelRevision: prov.Revision}).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods for revision %s", prov.Revision)
+	}
+	p := pod.New(r.obj.Clientset(), pod.WithLogger(r.log), pod.WithMaxLogLines(tailLines))
+	for _, i := range pods.Items {
+		pl := PodLogs{Name: i.GetName(), Namespace: i.GetNamespace(), Phase: string(i.Status.Phase), Lines: []string{}}
+		logs, err := p.GetLogs(ctx, types.NamespacedName{Namespace: i.GetNamespace(), Name: i.GetName()}, xpkg.RuntimeContainer)
+		if err != nil {
+			pl.Error = err.Error()
+			l.Pods = append(l.Pods, pl)
+			continue
+		}
+		if s := strings.TrimSuffix(string(logs), "\n"); s != "" {
+			lines := strings.Split(s, "\n")
+			pl.Scanned = len(lines)
+			pl.Lines = Matching(lines, l.Terms)
+		}
+		l.Pods = append(l.Pods, pl)
+	}
+	return l, nil
+}
+
+// Terms returns the terms that identify the supplied managed resource in
+// provider logs: its name, UID and external name.
+func Terms(mr *unstructured.Unstructured) []string {
+	terms := []string{mr.GetName()}
+	if uid := string(mr.GetUID()); uid != "" {
+		terms = append(terms, uid)
+	}
+	if en := meta.GetExternalName(mr); en != "" && !slices.Contains(terms, en) {
+		terms = append(terms, en)
+	}
+	return terms
+}
+
+// Matching returns the lines that contain at least one of the supplied
+// terms.
+func Matching(lines, terms []string) []string {
+	out := []string{}
+	for _, l := range lines {
+		if slices.ContainsFunc(terms, func(t string) bool { return t != "" && strings.Contains(l, t) }) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,175 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package managed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+var bucketGVK = schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta1", Kind: "Bucket"}
+
+func bucket() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind":       "Bucket",
+		"metadata": map[string]any{
+			"name":        "my-bucket",
+			"uid":         "bucket-uid",
+			"annotations": map[string]any{"crossplane.io/external-name": "my-bucket-x7k2"},
+		},
+	}}
+}
+
+func bucketCRD(owner string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "buckets.s3.aws.upbound.io"},
+	}}
+	if owner != "" {
+		u.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "pkg.crossplane.io/v1", Kind: kindProviderRevision, Name: owner}})
+	}
+	return u
+}
+
+func providerRevision(name, state string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "ProviderRevision",
+		"metadata": map[string]any{
+			"name":   name,
+			"labels": map[string]any{xpkg.LabelPackage: "provider-aws-s3"},
+		},
+		"spec": map[string]any{"desiredState": state},
+	}}
+}
+
+func TestLogs(t *testing.T) {
+	type want struct {
+		logs *Logs
+		err  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		objs   []*unstructured.Unstructured
+		want   want
+	}{
+		"ActiveRevision": {
+			reason: "Logs should be read from the pods of the provider's active revision, even if the CRD is owned by an older one.",
+			objs: []*unstructured.Unstructured{
+				bucket(),
+				bucketCRD("provider-aws-s3-old"),
+				providerRevision("provider-aws-s3-old", "Inactive"),
+				providerRevision("provider-aws-s3-new", "Active"),
+			},
+			want: want{
+				logs: &Logs{
+					Resource:     object.Summary{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket", Name: "my-bucket"},
+					ExternalName: "my-bucket-x7k2",
+					Provider:     Provider{Name: "provider-aws-s3", Revision: "provider-aws-s3-new", CRD: "buckets.s3.aws.upbound.io"},
+					Terms:        []string{"my-bucket", "bucket-uid", "my-bucket-x7k2"},
+					Pods: []PodLogs{{
+						Name:      "provider-aws-s3-new-123",
+						Namespace: "crossplane-system",
+						Phase:     "Running",
+						Scanned:   1,
+						Lines:     []string{},
+					}},
+				},
+			},
+		},
+		"NotAProvider": {
+			reason: "An error should be returned if the kind wasn't installed by a provider.",
+			objs:   []*unstructured.Unstructured{bucket(), bucketCRD("")},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cs := fake.NewClientset(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "crossplane-system",
+					Name:      "provider-aws-s3-new-123",
+					Labels:    map[string]string{xpkg.LabelRevision: "provider-aws-s3-new"},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			})
+			obj := objectfake.NewClientWithClientset(cs, nil, tc.objs)
+			r := New(obj, xpkg.New(obj, pod.New(cs)))
+
+			got, err := r.Logs(context.Background(), bucketGVK, types.NamespacedName{Name: "my-bucket"}, 100)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nLogs(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.logs, got); diff != "" {
+				t.Errorf("\n%s\nLogs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMatching(t *testing.T) {
+	lines := []string{
+		`{"level":"debug","msg":"Reconciling","request":"my-bucket"}`,
+		`{"level":"debug","msg":"Reconciling","request":"other-bucket"}`,
+		`{"level":"error","msg":"cannot observe external resource","external-name":"my-bucket-x7k2"}`,
+		`{"level":"info","msg":"Event","uid":"bucket-uid"}`,
+	}
+
+	cases := map[string]struct {
+		reason string
+		terms  []string
+		want   []string
+	}{
+		"AnyTerm": {
+			reason: "Lines containing any of the terms should be returned in order.",
+			terms:  []string{"my-bucket", "bucket-uid"},
+			want:   []string{lines[0], lines[2], lines[3]},
+		},
+		"EmptyTerm": {
+			reason: "Empty terms should not match every line.",
+			terms:  []string{""},
+			want:   []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Matching(lines, tc.terms)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMatching(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package managed
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+const (
+	// kindProviderConfig is the default kind of a managed resource's
+	// provider config.
+	kindProviderConfig = "ProviderConfig"
+	// defaultProviderConfig is the provider config used by managed
+	// resources that don't reference one.
+	defaultProviderConfig = "default"
+
+	// sourceSecret is the credentials source that reads a Secret.
+	sourceSecret = "Secret"
+)
+
+// identityEnv are the environment variables the provider pods need for
+// credential sources that rely on the pod's identity.
+var identityEnv = map[string][]string{
+	"IRSA":        {"AWS_ROLE_ARN", "AWS_WEB_IDENTITY_TOKEN_FILE"},
+	"PodIdentity": {"AWS_CONTAINER_CREDENTIALS_FULL_URI"},
+}
+
+// ProviderConfigCheck is the result of checking the provider config used by
+// a managed resource.
+type ProviderConfigCheck struct {
+	Resource       object.Summary `json:"resource"`
+	ProviderConfig object.Summary `json:"providerConfig"`
+	Found          bool           `json:"found"`
+	// Source of the provider config's credentials, e.g. Secret or IRSA.
+	Source string `json:"source,omitempty"`
+	// Users is the number of users the provider config reports.
+	Users int64 `json:"users"`
+	// Usages is the number of ProviderConfigUsages referencing the provider
+	// config.
+	Usages int `json:"usages"`
+	// ResourceTracked is whether a ProviderConfigUsage references the
+	// managed resource.
+	ResourceTracked bool            `json:"resourceTracked"`
+	Secrets         []SecretCheck   `json:"secrets"`
+	Identities      []IdentityCheck `json:"identities,omitempty"`
+	// Problems found with the provider config, if any.
+	Problems []string       `json:"problems"`
+	Events   []object.Event `json:"events"`
+}
+
+// SecretCheck reports whether a Secret referenced by a provider config and
+// its key exist. Secret values are never read into the result.
+type SecretCheck struct {
+	// Path of the reference within the provider config.
+	Path      string `json:"path"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key,omitempty"`
+	Exists    bool   `json:"exists"`
+	KeyExists bool   `json:"keyExists"`
+	// Skipped explains why the Secret wasn't checked.
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// IdentityCheck reports whether a provider pod has the environment needed to
+// use its workload identity.
+type IdentityCheck struct {
+	Pod            string   `json:"pod"`
+	Namespace      string   `json:"namespace"`
+	ServiceAccount string   `json:"serviceAccount"`
+	Present        []string `json:"present"`
+	Missing        []string `json:"missing"`
+}
+
+// CheckProviderConfig resolves the provider config referenced by the
+// supplied managed resource and checks its credentials.
+func (r *Resources) CheckProviderConfig(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*ProviderConfigCheck, error) {
+	mr, err := r.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	pv := fieldpath.Pave(mr.Object)
+	name, _ := pv.GetString("spec.providerConfigRef.name")
+	kind, _ := pv.GetString("spec.providerConfigRef.kind")
+	if name == "" {
+		name = defaultProviderConfig
+	}
+	if kind == "" {
+		kind = kindProviderConfig
+	}
+
+	m, err := r.providerConfigMapping(gvk.Group, kind)
+	if err != nil {
+		return nil, err
+	}
+	ns := ""
+	if m.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns = mr.GetNamespace()
+	}
+
+	c := &ProviderConfigCheck{
+		Resource: object.Summarize(mr),
+		ProviderConfig: object.Summary{
+			APIVersion: m.GroupVersionKind.GroupVersion().String(),
+			Kind:       kind,
+			Namespace:  ns,
+			Name:       name,
+		},
+		Secrets:  []SecretCheck{},
+		Problems: []string{},
+		Events:   []object.Event{},
+	}
+
+	pc, err := r.obj.Get(ctx, m.GroupVersionKind, types.NamespacedName{Namespace: ns, Name: name})
+	if kerrors.IsNotFound(err) {
+		c.Problems = append(c.Problems, fmt.Sprintf("%s %s does not exist", kind, name))
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.Found = true
+	c.ProviderConfig = object.Summarize(pc)
+	for _, cd := range c.ProviderConfig.Conditions {
+		if cd.Status != string(metav1.ConditionTrue) {
+			c.Problems = append(c.Problems, fmt.Sprintf("%s condition is %s: %s", cd.Type, cd.Status, cd.Message))
+		}
+	}
+
+	ppv := fieldpath.Pave(pc.Object)
+	c.Source, _ = ppv.GetString("spec.credentials.source")
+	c.Users, _ = ppv.GetInteger("status.users")
+
+	c.Secrets = r.checkSecrets(ctx, pc.Object["spec"], "spec", ns)
+	for _, s := range c.Secrets {
+		switch {
+		case s.Skipped != "":
+			// Not a problem with the Secret; the server can't read Secrets.
+		case s.Error != "":
+			c.Problems = append(c.Problems, fmt.Sprintf("cannot check Secret %s/%s referenced at %s: %s", s.Namespace, s.Name, s.Path, s.Error))
+		case !s.Exists:
+			c.Problems = append(c.Problems, fmt.Sprintf("Secret %s/%s referenced at %s does not exist", s.Namespace, s.Name, s.Path))
+		case s.Key != "" && !s.KeyExists:
+			c.Problems = append(c.Problems, fmt.Sprintf("Secret %s/%s referenced at %s has no key %s", s.Namespace, s.Name, s.Path, s.Key))
+		}
+	}
+	if c.Source == sourceSecret && len(c.Secrets) == 0 {
+		c.Problems = append(c.Problems, "credentials source is Secret but no Secret is referenced")
+	}
+
+	if env, ok := identityEnv[c.Source]; ok {
+		c.Identities, err = r.checkIdentities(ctx, gvk, env)
+		if err != nil {
+			c.Problems = append(c.Problems, fmt.Sprintf("cannot check the identity of the provider pods: %s", err))
+		}
+		for _, id := range c.Identities {
+			if len(id.Missing) > 0 {
+				c.Problems = append(c.Problems, fmt.Sprintf("provider pod %s/%s uses service account %s but lacks %s required by the %s credentials source", id.Namespace, id.Pod, id.ServiceAccount, strings.Join(id.Missing, ", "), c.Source))
+			}
+		}
+	}
+
+	c.Usages, c.ResourceTracked, err = r.usages(ctx, m.GroupVersionKind, kind, ns, name, object.ReferenceTo(mr))
+	if err != nil {
+		c.Problems = append(c.Problems, fmt.Sprintf("cannot count %sUsages: %s", kind, err))
+	} else if !c.ResourceTracked {
+		c.Problems = append(c.Problems, fmt.Sprintf("no %sUsage references the managed resource; the provider may not have reconciled it yet", kind))
+	}
+
+	if c.Events, err = r.obj.Events(ctx, pc); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// providerConfigMapping returns the mapping of the provider config kind
+// used by managed resources of the supplied group. Provider configs are
+// served by the managed resource's group or one of its parent groups, e.g.
+// aws.upbound.io for s3.aws.upbound.io.
+func (r *Resources) providerConfigMapping(group, kind string) (*meta.RESTMapping, error) {
+	for g := group; strings.Contains(g, "."); g = g[strings.Index(g, ".")+1:] {
+		m, err := r.obj.Mapper().RESTMapping(schema.GroupKind{Group: g, Kind: kind})
+		if err == nil {
+			return m, nil
+		}
+		if !meta.IsNoMatchError(err) {
+			return nil, errors.Wrapf(err, "cannot find %s kind for group %s", kind, g)
+		}
+	}
+	return nil, errors.Errorf("no %s kind is served for group %s or its parent groups", kind, group)
+}
+
+// checkSecrets checks the Secrets referenced anywhere in the supplied
+// provider config value. Any object named secretRef, or ending in SecretRef,
+// is treated as a reference. References without a namespace default to the
+// supplied namespace.
+func (r *Resources) checkSecrets(ctx context.Context, v any, path, namespace string) []SecretCheck {
+	out := []SecretCheck{}
+	switch t := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := path + "." + k
+			ref, ok := t[k].(map[string]any)
+			if ok && (k == "secretRef" || strings.HasSuffix(k, "SecretRef")) {
+				if name, _ := ref["name"].(string); name != "" {
+					ns, _ := ref["namespace"].(string)
+					key, _ := ref["key"].(string)
+					out = append(out, r.checkSecret(ctx, p, types.NamespacedName{Namespace: cmp.Or(ns, namespace), Name: name}, key))
+					continue
+				}
+			}
+			out = append(out, r.checkSecrets(ctx, t[k], p, namespace)...)
+		}
+	case []any:
+		for i, e := range t {
+			out = append(out, r.checkSecrets(ctx, e, fmt.Sprintf("%s[%d]", path, i), namespace)...)
+		}
+	}
+	return out
+}
+
+// checkSecret checks whether the supplied Secret and key exist.
+func (r *Resources) checkSecret(ctx context.Context, path string, nn types.NamespacedName, key string) SecretCheck {
+	sc := SecretCheck{Path: path, Namespace: nn.Namespace, Name: nn.Name, Key: key}
+	s, err := r.obj.Clientset().CoreV1().Secrets(nn.Namespace).Get(ctx, nn.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		return sc
+	case object.SecretSkipped(err) != "":
+		sc.Skipped = object.SecretSkipped(err)
+		return sc
+	case err != nil:
+		sc.Error = err.Error()
+		return sc
+	}
+	sc.Exists = true
+	if key != "" {
+		_, inData := s.Data[key]
+		_, inStringData := s.StringData[key]
+		sc.KeyExists = inData || inStringData
+	}
+	return sc
+}
+
+// checkIdentities checks that the pods of the provider that reconciles the
+// supplied kind have the supplied environment variables.
+func (r *Resources) checkIdentities(ctx context.Context, gvk schema.GroupVersionKind, env []string) ([]IdentityCheck, error) {
+	prov, err := r.Provider(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := r.obj.Clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{xpkg.LabelRevision: prov.Revision}).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods for revision %s", prov.Revision)
+	}
+
+	out := make([]IdentityCheck, 0, len(pods.Items))
+	for _, p := range pods.Items {
+		id := IdentityCheck{Pod: p.GetName(), Namespace: p.GetNamespace(), ServiceAccount: p.Spec.ServiceAccountName, Present: []string{}, Missing: []string{}}
+		for _, e := range env {
+			found := slices.ContainsFunc(p.Spec.Containers, func(c corev1.Container) bool {
+				return slices.ContainsFunc(c.Env, func(v corev1.EnvVar) bool { return v.Name == e })
+			})
+			if found {
+				id.Present = append(id.Present, e)
+				continue
+			}
+			id.Missing = append(id.Missing, e)
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// usages returns the number of usages of the named provider config and
+// whether any of them references the supplied managed resource.
+func (r *Resources) usages(ctx context.Context, pc schema.GroupVersionKind, kind, namespace, name string, mr object.Reference) (int, bool, error) {
+	m, err := r.obj.Mapper().RESTMapping(schema.GroupKind{Group: pc.Group, Kind: kind + "Usage"})
+	if err != nil {
+		return 0, false, err
+	}
+	l, err := r.obj.List(ctx, m.GroupVersionKind, namespace, metav1.ListOptions{})
+	if err != nil {
+		return 0, false, err
+	}
+
+	n, tracked := 0, false
+	for _, u := range l {
+		pv := fieldpath.Pave(u.Object)
+		if ref, _ := pv.GetString("providerConfigRef.name"); ref != name {
+			continue
+		}
+		if k, _ := pv.GetString("providerConfigRef.kind"); k != "" && k != kind {
+			continue
+		}
+		n++
+		rk, _ := pv.GetString("resourceRef.kind")
+		rn, _ := pv.GetString("resourceRef.name")
+		if rk == mr.Kind && rn == mr.Name {
+			tracked = true
+		}
+	}
+	return n, tracked, nil
+}
@@ -0,0 +1,155 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package managed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+func providerConfig(source string, credentials map[string]any) *unstructured.Unstructured {
+	credentials["source"] = source
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "aws.upbound.io/v1beta1",
+		"kind":       "ProviderConfig",
+		"metadata":   map[string]any{"name": "default"},
+		"spec":       map[string]any{"credentials": credentials},
+		"status":     map[string]any{"users": int64(1)},
+	}}
+}
+
+func usage(resource string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion":        "aws.upbound.io/v1beta1",
+		"kind":              "ProviderConfigUsage",
+		"metadata":          map[string]any{"name": "usage-" + resource},
+		"providerConfigRef": map[string]any{"name": "default"},
+		"resourceRef":       map[string]any{"apiVersion": "s3.aws.upbound.io/v1beta1", "kind": "Bucket", "name": resource},
+	}}
+}
+
+func TestCheckProviderConfig(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "aws-creds"},
+		Data:       map[string][]byte{"credentials": []byte("do-not-leak")},
+	}
+	ref := func(key string) map[string]any {
+		return map[string]any{"secretRef": map[string]any{"namespace": "crossplane-system", "name": "aws-creds", "key": key}}
+	}
+
+	type want struct {
+		found    bool
+		source   string
+		usages   int
+		tracked  bool
+		secrets  []SecretCheck
+		problems []string
+		err      bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		objs   []*unstructured.Unstructured
+		want   want
+	}{
+		"Healthy": {
+			reason: "A provider config whose Secret and key exist and that tracks the resource should have no problems.",
+			objs:   []*unstructured.Unstructured{bucket(), providerConfig("Secret", ref("credentials")), usage("my-bucket")},
+			want: want{
+				found:   true,
+				source:  "Secret",
+				usages:  1,
+				tracked: true,
+				secrets: []SecretCheck{{
+					Path: "spec.credentials.secretRef", Namespace: "crossplane-system", Name: "aws-creds", Key: "credentials", Exists: true, KeyExists: true,
+				}},
+				problems: []string{},
+			},
+		},
+		"MissingKey": {
+			reason: "A missing Secret key and an untracked resource should be reported as problems.",
+			objs:   []*unstructured.Unstructured{bucket(), providerConfig("Secret", ref("creds")), usage("other-bucket")},
+			want: want{
+				found:  true,
+				source: "Secret",
+				usages: 1,
+				secrets: []SecretCheck{{
+					Path: "spec.credentials.secretRef", Namespace: "crossplane-system", Name: "aws-creds", Key: "creds", Exists: true,
+				}},
+				problems: []string{
+					"Secret crossplane-system/aws-creds referenced at spec.credentials.secretRef has no key creds",
+					"no ProviderConfigUsage references the managed resource; the provider may not have reconciled it yet",
+				},
+			},
+		},
+		"NotFound": {
+			reason: "A provider config that doesn't exist should be reported as a problem rather than an error.",
+			objs: []*unstructured.Unstructured{bucket(), {Object: map[string]any{
+				"apiVersion": "aws.upbound.io/v1beta1",
+				"kind":       "ProviderConfig",
+				"metadata":   map[string]any{"name": "other"},
+			}}},
+			want: want{
+				secrets:  []SecretCheck{},
+				problems: []string{"ProviderConfig default does not exist"},
+			},
+		},
+		"NoProviderConfigKind": {
+			reason: "An error should be returned if no provider config kind is served for the resource's group.",
+			objs:   []*unstructured.Unstructured{bucket()},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := objectfake.NewClient(nil, tc.objs, secret)
+			r := New(obj, xpkg.New(obj, pod.New(obj.Clientset())))
+
+			c, err := r.CheckProviderConfig(context.Background(), bucketGVK, types.NamespacedName{Name: "my-bucket"})
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nCheckProviderConfig(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if err != nil {
+				return
+			}
+			got := want{
+				found:    c.Found,
+				source:   c.Source,
+				usages:   c.Usages,
+				tracked:  c.ResourceTracked,
+				secrets:  c.Secrets,
+				problems: c.Problems,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nCheckProviderConfig(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
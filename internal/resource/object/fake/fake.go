@@ -0,0 +1,96 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package fake provides a fake object.Client for use in tests.
+*/
+package fake
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+// Kind is a kind known to the fake client.
+type Kind struct {
+	GVK        schema.GroupVersionKind
+	Namespaced bool
+}
+
+// Mapper returns a RESTMapper that knows about the supplied kinds. Like a
+// discovery backed mapper it resolves kinds without a version to the version
+// they were added with.
+func Mapper(kinds ...Kind) meta.RESTMapper {
+	gvs := make([]schema.GroupVersion, 0, len(kinds))
+	for _, k := range kinds {
+		gvs = append(gvs, k.GVK.GroupVersion())
+	}
+	m := meta.NewDefaultRESTMapper(gvs)
+	for _, k := range kinds {
+		scope := meta.RESTScopeRoot
+		if k.Namespaced {
+			scope = meta.RESTScopeNamespace
+		}
+		m.Add(k.GVK, scope)
+	}
+	return m
+}
+
+// NewClient returns an object.Client backed by fake clients. The supplied
+// kinds, plus the kinds of the supplied objects, are known to the client.
+// Objects with a namespace are treated as namespaced. The typed clientset is
+// seeded with the supplied typed objects.
+func NewClient(kinds []Kind, objs []*unstructured.Unstructured, typed ...runtime.Object) *object.Client {
+	return object.New(fake.NewClientset(typed...), Dynamic(kinds, objs), Mapper(append(kinds, KindsOf(objs)...)...))
+}
+
+// NewClientWithClientset is like NewClient but uses the supplied typed
+// clientset.
+func NewClientWithClientset(cs kubernetes.Interface, kinds []Kind, objs []*unstructured.Unstructured) *object.Client {
+	return object.New(cs, Dynamic(kinds, objs), Mapper(append(kinds, KindsOf(objs)...)...))
+}
+
+// KindsOf returns the kinds of the supplied objects.
+func KindsOf(objs []*unstructured.Unstructured) []Kind {
+	kinds := make([]Kind, 0, len(objs))
+	for _, o := range objs {
+		kinds = append(kinds, Kind{GVK: o.GroupVersionKind(), Namespaced: o.GetNamespace() != ""})
+	}
+	return kinds
+}
+
+// Dynamic returns a fake dynamic client seeded with the supplied objects that
+// can list the supplied kinds.
+func Dynamic(kinds []Kind, objs []*unstructured.Unstructured) *dynamicfake.FakeDynamicClient {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, k := range append(kinds, KindsOf(objs)...) {
+		gvr, _ := meta.UnsafeGuessKindToResource(k.GVK)
+		listKinds[gvr] = k.GVK.Kind + "List"
+	}
+
+	ros := make([]runtime.Object, 0, len(objs))
+	for _, o := range objs {
+		ros = append(ros, o)
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, ros...)
+}
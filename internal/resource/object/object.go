@@ -0,0 +1,247 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package object provides tool helpers for working with arbitrary objects in
+the controlplane, such as Crossplane packages and composite resources.
+*/
+package object
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	// defaultMaxEvents to return to the caller.
+	defaultMaxEvents = 10
+
+	// listPageSize is the number of objects requested per page by Each.
+	listPageSize = 500
+)
+
+// Client reads arbitrary objects from the configured controlplane.
+type Client struct {
+	cs     kubernetes.Interface
+	dyn    dynamic.Interface
+	mapper meta.RESTMapper
+
+	// maximum number of events to return to the caller.
+	maxEvents int
+}
+
+// Option modifies the underlying Client.
+type Option func(*Client)
+
+// WithMaxEvents overrides the default MaxEvents setting.
+func WithMaxEvents(m int) Option {
+	return func(c *Client) {
+		c.maxEvents = m
+	}
+}
+
+// New constructs a new Client.
+func New(cs kubernetes.Interface, dyn dynamic.Interface, mapper meta.RESTMapper, opts ...Option) *Client {
+	c := &Client{
+		cs:     cs,
+		dyn:    dyn,
+		mapper: mapper,
+
+		maxEvents: defaultMaxEvents,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// Clientset returns the typed clientset used by the Client.
+func (c *Client) Clientset() kubernetes.Interface {
+	return c.cs
+}
+
+// Mapper returns the RESTMapper used by the Client.
+func (c *Client) Mapper() meta.RESTMapper {
+	return c.mapper
+}
+
+// resource returns the dynamic client for the supplied kind in the supplied
+// namespace. The namespace is ignored for cluster scoped kinds.
+func (c *Client) resource(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	m, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the API resource for %s", gvk)
+	}
+	if m.Scope.Name() == meta.RESTScopeNameRoot || namespace == "" {
+		return c.dyn.Resource(m.Resource), nil
+	}
+	return c.dyn.Resource(m.Resource).Namespace(namespace), nil
+}
+
+// Get the object of the supplied kind with the supplied name.
+func (c *Client) Get(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*unstructured.Unstructured, error) {
+	ri, err := c.resource(gvk, nn.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	u, err := ri.Get(ctx, nn.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %s", gvk.Kind, nn.Name)
+	}
+	return u, nil
+}
+
+// List the objects of the supplied kind in the supplied namespace. An empty
+// namespace lists objects across all namespaces.
+func (c *Client) List(ctx context.Context, gvk schema.GroupVersionKind, namespace string, opts metav1.ListOptions) ([]unstructured.Unstructured, error) {
+	ri, err := c.resource(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	l, err := ri.List(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s", gvk.Kind)
+	}
+	return l.Items, nil
+}
+
+// Each calls fn with every object of the supplied kind in the supplied
+// namespace. Objects are listed a page at a time so that sweeping a large
+// controlplane doesn't hold every object in memory at once. An empty
+// namespace lists objects across all namespaces.
+func (c *Client) Each(ctx context.Context, gvk schema.GroupVersionKind, namespace string, opts metav1.ListOptions, fn func(u *unstructured.Unstructured)) error {
+	ri, err := c.resource(gvk, namespace)
+	if err != nil {
+		return err
+	}
+	opts.Limit = listPageSize
+	for {
+		l, err := ri.List(ctx, opts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list %s", gvk.Kind)
+		}
+		for i := range l.Items {
+			fn(&l.Items[i])
+		}
+		if l.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = l.GetContinue()
+	}
+}
+
+// SecretSkipped explains why a Secret wasn't checked if the supplied error
+// means the server isn't allowed to get Secrets, or returns an empty string.
+// Secrets are only readable when the operator opts in, so this is reported as
+// a skipped check rather than a failed one.
+func SecretSkipped(err error) string {
+	if !kerrors.IsForbidden(err) {
+		return ""
+	}
+	return "not checked: the server isn't allowed to get Secrets; enable the chart's rbac.secretReader.enabled value to check them"
+}
+
+// DryRunCreate asks the API server to create the supplied object without
+// persisting it, running admission and validation as a real create would.
+// It returns the object as it would have been created.
+func (c *Client) DryRunCreate(ctx context.Context, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ri, err := c.resource(u.GroupVersionKind(), u.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return ri.Create(ctx, u, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+}
+
+// Events returns the most recent events involving the supplied object up to
+// the maximum number of events, ordered from oldest to most recent.
+func (c *Client) Events(ctx context.Context, u *unstructured.Unstructured) ([]Event, error) {
+	// Events for cluster scoped objects are recorded in the default
+	// namespace.
+	ns := u.GetNamespace()
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+
+	l, err := c.cs.CoreV1().Events(ns).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", u.GetName()).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to look up events for %s %s", u.GetKind(), u.GetName())
+	}
+
+	items := make([]corev1.Event, 0, len(l.Items))
+	for _, e := range l.Items {
+		if e.InvolvedObject.Name != u.GetName() {
+			continue
+		}
+		if e.InvolvedObject.Kind != "" && e.InvolvedObject.Kind != u.GetKind() {
+			continue
+		}
+		if e.InvolvedObject.UID != "" && u.GetUID() != "" && e.InvolvedObject.UID != u.GetUID() {
+			continue
+		}
+		items = append(items, e)
+	}
+
+	return ConvertEvents(items, c.maxEvents), nil
+}
+
+// ConvertEvents orders the supplied events from oldest to most recent and
+// converts the most recent up to the supplied maximum.
+func ConvertEvents(items []corev1.Event, maxEvents int) []Event {
+	slices.SortStableFunc(items, func(a, b corev1.Event) int {
+		return LastSeen(a).Compare(LastSeen(b))
+	})
+	if maxEvents > 0 && len(items) > maxEvents {
+		items = items[len(items)-maxEvents:]
+	}
+
+	events := make([]Event, 0, len(items))
+	for _, e := range items {
+		events = append(events, ConvertEvent(e))
+	}
+	return events
+}
+
+// LastSeen returns the most recent time the supplied event was observed.
+func LastSeen(e corev1.Event) time.Time {
+	switch {
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
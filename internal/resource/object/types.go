@@ -0,0 +1,170 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package object
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+// Summary summarizes the state of a Kubernetes resource.
+type Summary struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Namespace  string      `json:"namespace,omitempty"`
+	Name       string      `json:"name"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Condition is a status condition of a Kubernetes resource.
+type Condition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// Event is a Kubernetes event minus the attributes that aren't useful for
+// analysis.
+type Event struct {
+	Type                string `json:"type,omitempty"`
+	Reason              string `json:"reason"`
+	Message             string `json:"message"`
+	EventTime           string `json:"eventTime"`
+	Action              string `json:"action"`
+	ReportingController string `json:"reportingController"`
+	ReportingInstance   string `json:"reportingInstance"`
+	Related             string `json:"related"`
+	FirstTimestamp      string `json:"firstTimestamp"`
+	LastTimestamp       string `json:"lastTimestamp"`
+	// Count is the number of times the event was observed.
+	Count int32 `json:"count,omitempty"`
+}
+
+// Reference identifies an object in the controlplane.
+type Reference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ReferenceTo returns a Reference to the supplied object.
+func ReferenceTo(u *unstructured.Unstructured) Reference {
+	return Reference{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+	}
+}
+
+// Summarize the supplied object.
+func Summarize(u *unstructured.Unstructured) Summary {
+	return Summary{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+		Conditions: Conditions(u),
+	}
+}
+
+// Conditions returns the status conditions of the supplied object.
+func Conditions(u *unstructured.Unstructured) []Condition {
+	var cs []xpv1.Condition
+	if err := fieldpath.Pave(u.Object).GetValueInto("status.conditions", &cs); err != nil {
+		return nil
+	}
+
+	out := make([]Condition, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, Condition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             string(c.Reason),
+			Message:            c.Message,
+			LastTransitionTime: FormatTime(c.LastTransitionTime.Time),
+		})
+	}
+	return out
+}
+
+// FindCondition returns the condition of the supplied type, if any.
+func FindCondition(cs []Condition, t string) (Condition, bool) {
+	for _, c := range cs {
+		if c.Type == t {
+			return c, true
+		}
+	}
+	return Condition{}, false
+}
+
+// ConvertEvent converts the supplied corev1.Event into an Event.
+func ConvertEvent(e corev1.Event) Event {
+	related := ""
+	if e.Related != nil {
+		related = e.Related.GroupVersionKind().String()
+	}
+
+	count := e.Count
+	if e.Series != nil {
+		count = e.Series.Count
+	}
+
+	return Event{
+		Type:                e.Type,
+		Reason:              e.Reason,
+		Message:             e.Message,
+		EventTime:           e.EventTime.String(),
+		Action:              e.Action,
+		ReportingController: e.ReportingController,
+		ReportingInstance:   e.ReportingInstance,
+		Related:             related,
+		FirstTimestamp:      e.FirstTimestamp.String(),
+		LastTimestamp:       e.LastTimestamp.String(),
+		Count:               count,
+	}
+}
+
+// FormatTime formats the supplied time for output, returning an empty string
+// for the zero time.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Number returns the supplied unstructured field value as a float, if it is a
+// number. Unstructured objects hold integers as int64 and everything else as
+// float64.
+func Number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
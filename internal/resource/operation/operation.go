@@ -0,0 +1,396 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package operation provides tool helpers for working with Crossplane v2
+Operations, CronOperations and WatchOperations.
+*/
+package operation
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// Group of the Crossplane operations APIs.
+	Group = "ops.crossplane.io"
+
+	// KindOperation is the kind of an Operation.
+	KindOperation = "Operation"
+	// KindCronOperation is the kind of a CronOperation.
+	KindCronOperation = "CronOperation"
+	// KindWatchOperation is the kind of a WatchOperation.
+	KindWatchOperation = "WatchOperation"
+
+	// PhaseRunning is the phase of an Operation that hasn't completed.
+	PhaseRunning = "Running"
+	// PhaseSucceeded is the phase of an Operation that succeeded.
+	PhaseSucceeded = "Succeeded"
+	// PhaseFailed is the phase of an Operation that failed.
+	PhaseFailed = "Failed"
+
+	// conditionSucceeded is the condition Operations use to report whether
+	// they completed successfully.
+	conditionSucceeded = "Succeeded"
+
+	// maxHistory is the number of Operations created by a CronOperation or
+	// WatchOperation returned in its history.
+	maxHistory = 10
+
+	errList = "cannot list operations; they require Crossplane v2 with the Operations feature enabled"
+)
+
+// Kinds returns the supported operation kinds.
+func Kinds() []string {
+	return []string{KindOperation, KindCronOperation, KindWatchOperation}
+}
+
+// GVK returns the GroupVersionKind of the supplied operation kind.
+func GVK(kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1alpha1", Kind: kind}
+}
+
+// Summary summarizes an Operation, CronOperation or WatchOperation.
+type Summary struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Created string `json:"created"`
+
+	// Phase of an Operation: Running, Succeeded or Failed.
+	Phase string `json:"phase,omitempty"`
+	// Parent is the CronOperation or WatchOperation that created an
+	// Operation, e.g. CronOperation/nightly-backup.
+	Parent    string `json:"parent,omitempty"`
+	Failures  int64  `json:"failures,omitempty"`
+	Completed string `json:"completed,omitempty"`
+	Message   string `json:"message,omitempty"`
+
+	// Schedule of a CronOperation.
+	Schedule string `json:"schedule,omitempty"`
+	// Watch is the kind watched by a WatchOperation, e.g. example.org/v1/App.
+	Watch              string `json:"watch,omitempty"`
+	Suspended          bool   `json:"suspended,omitempty"`
+	Running            int    `json:"running,omitempty"`
+	LastScheduleTime   string `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime string `json:"lastSuccessfulTime,omitempty"`
+}
+
+// Listing is a list of operations.
+type Listing struct {
+	Operations []Summary `json:"operations"`
+}
+
+// Details describes an Operation, CronOperation or WatchOperation in depth.
+type Details struct {
+	object.Summary
+	Overview Summary `json:"overview"`
+
+	// RetryLimit, Steps and AppliedResources are set for Operations.
+	RetryLimit       int64              `json:"retryLimit,omitempty"`
+	Steps            []Step             `json:"steps,omitempty"`
+	AppliedResources []object.Reference `json:"appliedResources,omitempty"`
+	// FailureMessages are the messages of the conditions that aren't True.
+	FailureMessages []string `json:"failureMessages,omitempty"`
+
+	// ConcurrencyPolicy, WatchingResources, RunningOperations and History
+	// are set for CronOperations and WatchOperations.
+	ConcurrencyPolicy string   `json:"concurrencyPolicy,omitempty"`
+	WatchingResources int64    `json:"watchingResources,omitempty"`
+	RunningOperations []string `json:"runningOperations,omitempty"`
+	// History of the most recent Operations created, most recent first.
+	History []Summary `json:"history,omitempty"`
+	// OmittedHistory is the number of older Operations created that aren't
+	// in the History.
+	OmittedHistory int `json:"omittedHistory,omitempty"`
+
+	Events []object.Event `json:"events"`
+}
+
+// Step is a step of an Operation's pipeline and its output.
+type Step struct {
+	Name        string         `json:"name"`
+	FunctionRef string         `json:"functionRef"`
+	Output      map[string]any `json:"output,omitempty"`
+}
+
+// Touch lists the Operations that applied a resource.
+type Touch struct {
+	Resource   object.Reference `json:"resource"`
+	Operations []Summary        `json:"operations"`
+}
+
+// Operations provides methods for deriving details for operations in the
+// configured controlplane.
+type Operations struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Operations.
+type Option func(*Operations)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(o *Operations) {
+		o.log = log
+	}
+}
+
+// New constructs a new Operations.
+func New(obj *object.Client, opts ...Option) *Operations {
+	o := &Operations{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	return o
+}
+
+// List the operations of the supplied kinds, most recently created first.
+func (o *Operations) List(ctx context.Context, kinds ...string) (*Listing, error) {
+	l := &Listing{Operations: []Summary{}}
+	for _, k := range kinds {
+		err := o.each(ctx, k, func(u *unstructured.Unstructured) {
+			l.Operations = append(l.Operations, summarize(u))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortRecent(l.Operations)
+	return l, nil
+}
+
+// Get details of the operation of the supplied kind and name.
+func (o *Operations) Get(ctx context.Context, kind, name string) (*Details, error) {
+	u, err := o.obj.Get(ctx, GVK(kind), types.NamespacedName{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	d := &Details{
+		Summary:  object.Summarize(u),
+		Overview: summarize(u),
+	}
+	for _, c := range d.Conditions {
+		if c.Status != string(metav1.ConditionTrue) && c.Message != "" {
+			d.FailureMessages = append(d.FailureMessages, c.Message)
+		}
+	}
+	if d.Events, err = o.obj.Events(ctx, u); err != nil {
+		return nil, err
+	}
+
+	pv := fieldpath.Pave(u.Object)
+	if kind == KindOperation {
+		d.RetryLimit, _ = pv.GetInteger("spec.retryLimit")
+		d.Steps = steps(pv)
+		_ = pv.GetValueInto("status.appliedResourceRefs", &d.AppliedResources)
+		return d, nil
+	}
+
+	d.ConcurrencyPolicy, _ = pv.GetString("spec.concurrencyPolicy")
+	d.WatchingResources, _ = pv.GetInteger("status.watchingResources")
+	d.RunningOperations = runningOperations(pv)
+
+	d.History = []Summary{}
+	err = o.each(ctx, KindOperation, func(u *unstructured.Unstructured) {
+		if parent(u) == kind+"/"+name {
+			d.History = append(d.History, summarize(u))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortRecent(d.History)
+	if len(d.History) > maxHistory {
+		d.History, d.OmittedHistory = d.History[:maxHistory], len(d.History)-maxHistory
+	}
+	return d, nil
+}
+
+// Touched returns the Operations that applied the supplied resource, most
+// recently completed first.
+func (o *Operations) Touched(ctx context.Context, ref object.Reference) (*Touch, error) {
+	want := o.keyOf(ref)
+	t := &Touch{Resource: ref, Operations: []Summary{}}
+	err := o.each(ctx, KindOperation, func(u *unstructured.Unstructured) {
+		var refs []object.Reference
+		_ = fieldpath.Pave(u.Object).GetValueInto("status.appliedResourceRefs", &refs)
+		if slices.ContainsFunc(refs, func(r object.Reference) bool { return o.keyOf(r) == want }) {
+			t.Operations = append(t.Operations, summarize(u))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(t.Operations, func(a, b Summary) int {
+		return cmp.Compare(cmp.Or(b.Completed, b.Created), cmp.Or(a.Completed, a.Created))
+	})
+	return t, nil
+}
+
+// key identifies a resource regardless of the version it is referenced at.
+type key struct {
+	schema.GroupKind
+	Namespace string
+	Name      string
+}
+
+// keyOf returns the key of the supplied reference. Cluster scoped resources
+// have no namespace, and namespaced resources referenced without one are in
+// the default namespace.
+func (o *Operations) keyOf(ref object.Reference) key {
+	gv, _ := schema.ParseGroupVersion(ref.APIVersion)
+	k := key{GroupKind: schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, Namespace: ref.Namespace, Name: ref.Name}
+	m, err := o.obj.Mapper().RESTMapping(k.GroupKind)
+	if err != nil {
+		return k
+	}
+	switch {
+	case m.Scope.Name() == meta.RESTScopeNameRoot:
+		k.Namespace = ""
+	case k.Namespace == "":
+		k.Namespace = metav1.NamespaceDefault
+	}
+	return k
+}
+
+// each calls fn with every operation of the supplied kind, listing them a
+// page at a time.
+func (o *Operations) each(ctx context.Context, kind string, fn func(u *unstructured.Unstructured)) error {
+	return errors.Wrap(o.obj.Each(ctx, GVK(kind), "", metav1.ListOptions{}, fn), errList)
+}
+
+// summarize the supplied operation.
+func summarize(u *unstructured.Unstructured) Summary {
+	pv := fieldpath.Pave(u.Object)
+	str := func(path string) string {
+		s, _ := pv.GetString(path)
+		return s
+	}
+
+	s := Summary{
+		Kind:    u.GetKind(),
+		Name:    u.GetName(),
+		Created: object.FormatTime(u.GetCreationTimestamp().Time),
+	}
+
+	switch u.GetKind() {
+	case KindOperation:
+		s.Parent = parent(u)
+		s.Failures, _ = pv.GetInteger("status.failures")
+		s.Phase = PhaseRunning
+		if c, ok := object.FindCondition(object.Conditions(u), conditionSucceeded); ok {
+			switch c.Status {
+			case string(metav1.ConditionTrue):
+				s.Phase, s.Completed = PhaseSucceeded, c.LastTransitionTime
+			case string(metav1.ConditionFalse):
+				s.Phase, s.Completed, s.Message = PhaseFailed, c.LastTransitionTime, c.Message
+			}
+		}
+	case KindCronOperation, KindWatchOperation:
+		s.Schedule = str("spec.schedule")
+		if k := str("spec.watch.kind"); k != "" {
+			s.Watch = str("spec.watch.apiVersion") + "/" + k
+		}
+		s.Suspended, _ = pv.GetBool("spec.suspend")
+		s.Running = len(runningOperations(pv))
+		s.LastScheduleTime = str("status.lastScheduleTime")
+		s.LastSuccessfulTime = str("status.lastSuccessfulTime")
+	}
+	return s
+}
+
+// parent returns the CronOperation or WatchOperation that controls the
+// supplied Operation, e.g. CronOperation/nightly-backup.
+func parent(u *unstructured.Unstructured) string {
+	ref := metav1.GetControllerOf(u)
+	if ref == nil || (ref.Kind != KindCronOperation && ref.Kind != KindWatchOperation) {
+		return ""
+	}
+	return ref.Kind + "/" + ref.Name
+}
+
+// stepOutput is the output of an Operation's pipeline step.
+type stepOutput struct {
+	Step   string         `json:"step"`
+	Output map[string]any `json:"output"`
+}
+
+// steps returns the pipeline steps of an Operation along with their output.
+func steps(pv *fieldpath.Paved) []Step {
+	var spec []struct {
+		Step        string `json:"step"`
+		FunctionRef struct {
+			Name string `json:"name"`
+		} `json:"functionRef"`
+	}
+	var status []stepOutput
+	_ = pv.GetValueInto("spec.pipeline", &spec)
+	_ = pv.GetValueInto("status.pipeline", &status)
+
+	out := make([]Step, 0, len(spec))
+	for _, s := range spec {
+		st := Step{Name: s.Step, FunctionRef: s.FunctionRef.Name}
+		if i := slices.IndexFunc(status, func(o stepOutput) bool { return o.Step == s.Step }); i >= 0 {
+			st.Output = status[i].Output
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+// runningOperations returns the names of the Operations a CronOperation or
+// WatchOperation is running.
+func runningOperations(pv *fieldpath.Paved) []string {
+	var refs []struct {
+		Name string `json:"name"`
+	}
+	_ = pv.GetValueInto("status.runningOperationRefs", &refs)
+	out := make([]string, 0, len(refs))
+	for _, r := range refs {
+		out = append(out, r.Name)
+	}
+	return out
+}
+
+// sortRecent sorts the supplied summaries, most recently created first.
+func sortRecent(s []Summary) {
+	slices.SortStableFunc(s, func(a, b Summary) int {
+		if c := cmp.Compare(b.Created, a.Created); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
@@ -0,0 +1,247 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package operation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var appRef = map[string]any{"apiVersion": "example.org/v1", "kind": "App", "namespace": "default", "name": "my-app"}
+
+func op(name, created, status, parent string, refs ...any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "ops.crossplane.io/v1alpha1",
+		"kind":       KindOperation,
+		"metadata": map[string]any{
+			"name":              name,
+			"creationTimestamp": created,
+		},
+		"spec": map[string]any{
+			"retryLimit": int64(5),
+			"pipeline": []any{
+				map[string]any{"step": "rotate", "functionRef": map[string]any{"name": "function-rotate"}},
+			},
+		},
+		"status": map[string]any{
+			"pipeline":            []any{map[string]any{"step": "rotate", "output": map[string]any{"rotated": int64(1)}}},
+			"appliedResourceRefs": refs,
+		},
+	}}
+	if status != "" {
+		u.Object["status"].(map[string]any)["conditions"] = []any{map[string]any{
+			"type":               "Succeeded",
+			"status":             status,
+			"reason":             "PipelineError",
+			"message":            "boom",
+			"lastTransitionTime": created,
+		}}
+	}
+	if parent != "" {
+		ctrl := true
+		_ = unstructured.SetNestedSlice(u.Object, []any{map[string]any{
+			"apiVersion": "ops.crossplane.io/v1alpha1",
+			"kind":       KindCronOperation,
+			"name":       parent,
+			"uid":        parent + "-uid",
+			"controller": ctrl,
+		}}, "metadata", "ownerReferences")
+	}
+	return u
+}
+
+func cron(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "ops.crossplane.io/v1alpha1",
+		"kind":       KindCronOperation,
+		"metadata":   map[string]any{"name": name, "creationTimestamp": "2025-01-01T00:00:00Z"},
+		"spec":       map[string]any{"schedule": "0 * * * *", "concurrencyPolicy": "Forbid"},
+		"status": map[string]any{
+			"lastScheduleTime":     "2025-01-02T01:00:00Z",
+			"runningOperationRefs": []any{map[string]any{"name": "rotate-2"}},
+		},
+	}}
+}
+
+func kinds() []objectfake.Kind {
+	out := make([]objectfake.Kind, 0, 3)
+	for _, k := range Kinds() {
+		out = append(out, objectfake.Kind{GVK: GVK(k)})
+	}
+	return out
+}
+
+func TestList(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		op("rotate-1", "2025-01-02T00:00:00Z", "False", "rotate"),
+		op("rotate-2", "2025-01-02T01:00:00Z", "", "rotate"),
+		cron("rotate"),
+	}
+
+	got, err := New(objectfake.NewClient(kinds(), objs)).List(context.Background(), Kinds()...)
+	if err != nil {
+		t.Fatalf("List(...): %v", err)
+	}
+	want := &Listing{Operations: []Summary{
+		{Kind: KindOperation, Name: "rotate-2", Created: "2025-01-02T01:00:00Z", Phase: PhaseRunning, Parent: "CronOperation/rotate"},
+		{Kind: KindOperation, Name: "rotate-1", Created: "2025-01-02T00:00:00Z", Phase: PhaseFailed, Parent: "CronOperation/rotate", Completed: "2025-01-02T00:00:00Z", Message: "boom"},
+		{Kind: KindCronOperation, Name: "rotate", Created: "2025-01-01T00:00:00Z", Schedule: "0 * * * *", Running: 1, LastScheduleTime: "2025-01-02T01:00:00Z"},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nList(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGetHistoryLarge(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	objs := []*unstructured.Unstructured{cron("rotate")}
+	for i := range 15 {
+		objs = append(objs, op(fmt.Sprintf("op-%03d", i), start.Add(time.Duration(i)*time.Minute).Format(time.RFC3339), "True", "rotate"))
+	}
+
+	d, err := New(objectfake.NewClient(kinds(), objs)).Get(context.Background(), KindCronOperation, "rotate")
+	if err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if diff := cmp.Diff([]any{maxHistory, "op-014", 15 - maxHistory}, []any{len(d.History), d.History[0].Name, d.OmittedHistory}); diff != "" {
+		t.Errorf("\nGet(...): only the most recent Operations should be in the history, and the rest counted: -want, +got:\n%s", diff)
+	}
+}
+
+func TestGet(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		op("rotate-1", "2025-01-02T00:00:00Z", "False", "rotate", appRef),
+		op("adhoc", "2025-01-02T02:00:00Z", "True", ""),
+		cron("rotate"),
+	}
+
+	cases := map[string]struct {
+		reason string
+		kind   string
+		name   string
+		got    func(*Details) any
+		want   any
+	}{
+		"Operation": {
+			reason: "An Operation's steps, applied resources and failure messages should be returned.",
+			kind:   KindOperation,
+			name:   "rotate-1",
+			got: func(d *Details) any {
+				return []any{d.RetryLimit, d.Steps, d.AppliedResources, d.FailureMessages}
+			},
+			want: []any{
+				int64(5),
+				[]Step{{Name: "rotate", FunctionRef: "function-rotate", Output: map[string]any{"rotated": int64(1)}}},
+				[]object.Reference{{APIVersion: "example.org/v1", Kind: "App", Namespace: "default", Name: "my-app"}},
+				[]string{"boom"},
+			},
+		},
+		"CronOperation": {
+			reason: "A CronOperation's running Operations and the Operations it created should be returned.",
+			kind:   KindCronOperation,
+			name:   "rotate",
+			got: func(d *Details) any {
+				return []any{d.ConcurrencyPolicy, d.RunningOperations, d.History}
+			},
+			want: []any{"Forbid", []string{"rotate-2"}, []Summary{{
+				Kind:      KindOperation,
+				Name:      "rotate-1",
+				Created:   "2025-01-02T00:00:00Z",
+				Phase:     PhaseFailed,
+				Parent:    "CronOperation/rotate",
+				Completed: "2025-01-02T00:00:00Z",
+				Message:   "boom",
+			}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := New(objectfake.NewClient(kinds(), objs)).Get(context.Background(), tc.kind, tc.name)
+			if err != nil {
+				t.Fatalf("\n%s\nGet(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, tc.got(d)); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTouched(t *testing.T) {
+	appRefAt := func(apiVersion, namespace string) map[string]any {
+		return map[string]any{"apiVersion": apiVersion, "kind": "App", "namespace": namespace, "name": "my-app"}
+	}
+	objs := []*unstructured.Unstructured{
+		op("older", "2025-01-01T00:00:00Z", "True", "", appRef),
+		op("newer", "2025-01-02T00:00:00Z", "True", "", appRefAt("example.org/v1beta1", "default")),
+		op("defaulted", "2025-01-03T00:00:00Z", "True", "", appRefAt("example.org/v1", "")),
+		op("elsewhere", "2025-01-04T00:00:00Z", "True", "", appRefAt("example.org/v1", "other")),
+		op("unrelated", "2025-01-05T00:00:00Z", "True", ""),
+	}
+	ks := append(kinds(),
+		objectfake.Kind{GVK: schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "App"}, Namespaced: true},
+		objectfake.Kind{GVK: schema.GroupVersionKind{Group: "example.org", Version: "v1beta1", Kind: "App"}, Namespaced: true},
+	)
+
+	cases := map[string]struct {
+		reason string
+		ref    object.Reference
+		want   []string
+	}{
+		"Namespaced": {
+			reason: "Operations that applied the resource at any version should be returned, treating a missing namespace as the default namespace.",
+			ref:    object.Reference{APIVersion: "example.org/v1", Kind: "App", Namespace: "default", Name: "my-app"},
+			want:   []string{"defaulted", "newer", "older"},
+		},
+		"DefaultNamespace": {
+			reason: "A resource supplied without a namespace should match references in the default namespace.",
+			ref:    object.Reference{APIVersion: "example.org/v1beta1", Kind: "App", Name: "my-app"},
+			want:   []string{"defaulted", "newer", "older"},
+		},
+		"OtherNamespace": {
+			reason: "Operations that applied a resource of the same name in another namespace should not be returned.",
+			ref:    object.Reference{APIVersion: "example.org/v1", Kind: "App", Namespace: "other", Name: "my-app"},
+			want:   []string{"elsewhere"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(objectfake.NewClient(ks, objs)).Touched(context.Background(), tc.ref)
+			if err != nil {
+				t.Fatalf("Touched(...): %v", err)
+			}
+			names := make([]string, 0, len(got.Operations))
+			for _, o := range got.Operations {
+				names = append(names, o.Name)
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Errorf("\n%s\nTouched(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
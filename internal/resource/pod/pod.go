@@ -21,9 +21,7 @@ package pod
 
 import (
 	"context"
-	"encoding/json"
 	"io"
-	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +32,8 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
 )
 
 const (
@@ -72,6 +72,13 @@ func WithMaxEvents(m int) Option {
 	}
 }
 
+// WithMaxLogLines overrides the default MaxLogLines setting.
+func WithMaxLogLines(m int64) Option {
+	return func(p *Pod) {
+		p.maxLogLines = m
+	}
+}
+
 // New constructs a new Pod.
 func New(cs kubernetes.Interface, opts ...Option) *Pod {
 	p := &Pod{
@@ -89,10 +96,12 @@ func New(cs kubernetes.Interface, opts ...Option) *Pod {
 	return p
 }
 
-// GetLogs returns the logs from the supplied Pod up to the maximum number of
-// log lines.
-func (p *Pod) GetLogs(ctx context.Context, nn types.NamespacedName) ([]byte, error) {
+// GetLogs returns the logs from the supplied container of the supplied Pod up
+// to the maximum number of log lines. An empty container reads the Pod's only
+// container, or its default container if it has more than one.
+func (p *Pod) GetLogs(ctx context.Context, nn types.NamespacedName, container string) ([]byte, error) {
 	req := p.cs.CoreV1().Pods(nn.Namespace).GetLogs(nn.Name, &corev1.PodLogOptions{
+		Container: container,
 		TailLines: ptr.To(p.maxLogLines),
 	})
 	logs, err := req.Stream(ctx)
@@ -112,9 +121,9 @@ func (p *Pod) GetLogs(ctx context.Context, nn types.NamespacedName) ([]byte, err
 	return buf, nil
 }
 
-// GetEvents returns the events for the correlated to the supplied pod up to
-// the maximum number of events.
-func (p *Pod) GetEvents(ctx context.Context, nn types.NamespacedName) ([]byte, error) {
+// GetEvents returns the most recent events correlated to the supplied pod up
+// to the maximum number of events, ordered from oldest to most recent.
+func (p *Pod) GetEvents(ctx context.Context, nn types.NamespacedName) ([]object.Event, error) {
 	pod, err := p.cs.CoreV1().Pods(nn.Namespace).Get(ctx, nn.Name, metav1.GetOptions{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to look up pod")
@@ -127,69 +136,5 @@ func (p *Pod) GetEvents(ctx context.Context, nn types.NamespacedName) ([]byte, e
 		return nil, errors.Wrap(err, "failed to look up events for pod")
 	}
 
-	seen := 0
-	var sb strings.Builder
-
-	for _, i := range eventList.Items {
-		if seen >= p.maxEvents {
-			break // we have enough
-		}
-		e := convert(i)
-		s, err := e.string()
-		if err != nil {
-			p.log.Info("failed to marshal event", "error", err)
-			// Skip this event in the event we have more useful data
-			// to return.
-			continue
-		}
-
-		sb.WriteString(s)
-		seen++
-	}
-
-	// TODO(tnthornton) consider replacing this conversion.
-	return []byte(sb.String()), nil
-}
-
-// event is a helper for logging corev1.Event details minus some attributes
-// that we don't currently care about.
-type event struct {
-	Reason              string `json:"reason"`
-	Message             string `json:"message"`
-	EventTime           string `json:"eventTime"`
-	Action              string `json:"action"`
-	ReportingController string `json:"reportingController"`
-	ReportingInstance   string `json:"reportingInstance"`
-	Related             string `json:"related"`
-	FirstTimestamp      string `json:"firstTimestamp"`
-	LastTimestamp       string `json:"lastTimestamp"`
-}
-
-// convert the given corev1.Event into a event for cleaner log data.
-func convert(e corev1.Event) *event {
-	related := ""
-	if e.Related != nil {
-		related = e.Related.GroupVersionKind().String()
-	}
-
-	return &event{
-		Reason:              e.Reason,
-		Message:             e.Message,
-		EventTime:           e.EventTime.String(),
-		Action:              e.Action,
-		ReportingController: e.ReportingController,
-		ReportingInstance:   e.ReportingInstance,
-		Related:             related,
-		FirstTimestamp:      e.FirstTimestamp.String(),
-		LastTimestamp:       e.LastTimestamp.String(),
-	}
-}
-
-// stringify the event for sending back to the client.
-func (e *event) string() (string, error) {
-	b, err := json.Marshal(e)
-	if err != nil {
-		return "", errors.Wrap(err, "event content is broken")
-	}
-	return string(b), nil
+	return object.ConvertEvents(eventList.Items, p.maxEvents), nil
 }
@@ -17,9 +17,7 @@
 package pod
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -66,7 +64,7 @@ func TestGetLogs(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			p := New(tc.args.cs)
-			got, err := p.GetLogs(context.Background(), tc.args.nn)
+			got, err := p.GetLogs(context.Background(), tc.args.nn, "")
 
 			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nGetLogs(...): -want err, +got err:\n%s", tc.reason, diff)
@@ -86,7 +84,10 @@ func TestGetEvents(t *testing.T) {
 	}
 	type want struct {
 		numEvents int
-		err       error
+		// warnings is the number of Warning events observed more than
+		// once.
+		warnings int
+		err      error
 	}
 
 	cases := map[string]struct {
@@ -95,7 +96,7 @@ func TestGetEvents(t *testing.T) {
 		want   want
 	}{
 		"NoEvents": {
-			reason: "If the pod is available but there are no events, and empty string should be returned.",
+			reason: "If the pod is available but there are no events, no events should be returned.",
 			args: args{
 				cs: fake.NewClientset(&corev1.Pod{
 					ObjectMeta: metav1.ObjectMeta{
@@ -128,8 +129,10 @@ func TestGetEvents(t *testing.T) {
 					InvolvedObject: corev1.ObjectReference{
 						Name: "pod-1",
 					},
+					Type:    corev1.EventTypeWarning,
 					Reason:  "some reason",
 					Message: "some message",
+					Count:   3,
 				},
 					&corev1.Event{
 						ObjectMeta: metav1.ObjectMeta{
@@ -149,6 +152,7 @@ func TestGetEvents(t *testing.T) {
 			},
 			want: want{
 				numEvents: 2,
+				warnings:  1,
 			},
 		},
 		"MoreEventThanMax": {
@@ -186,11 +190,21 @@ func TestGetEvents(t *testing.T) {
 			got, err := p.GetEvents(context.Background(), tc.args.nn)
 
 			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nGetLogs(...): -want err, +got err:\n%s", tc.reason, diff)
+				t.Errorf("\n%s\nGetEvents(...): -want err, +got err:\n%s", tc.reason, diff)
 			}
 
-			if diff := cmp.Diff(tc.want.numEvents, count(t, got)); diff != "" {
-				t.Errorf("\n%s\nGetLogs(...): -want, +got:\n%s", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.numEvents, len(got)); diff != "" {
+				t.Errorf("\n%s\nGetEvents(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			warnings := 0
+			for _, e := range got {
+				if e.Type == corev1.EventTypeWarning && e.Count > 1 {
+					warnings++
+				}
+			}
+			if diff := cmp.Diff(tc.want.warnings, warnings); diff != "" {
+				t.Errorf("\n%s\nGetEvents(...): event type and count should be kept: -want, +got:\n%s", tc.reason, diff)
 			}
 		})
 	}
@@ -216,22 +230,3 @@ func getEvents(n int) []*corev1.Event {
 
 	return list
 }
-
-// count the number of individual events in the JSON stream.
-func count(t *testing.T, el []byte) int {
-	t.Helper()
-
-	d := json.NewDecoder(bytes.NewReader(el))
-
-	events := make([]event, 0)
-
-	for d.More() {
-		var e event
-		if err := d.Decode(&e); err != nil {
-			t.Fatalf("failed to decode event in stream: %v", err)
-		}
-		events = append(events, e)
-	}
-
-	return len(events)
-}
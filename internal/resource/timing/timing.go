@@ -0,0 +1,436 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package timing provides tool helpers for estimating when Crossplane last
+reconciled a managed or composite resource and when it will do so next.
+*/
+package timing
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/core"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/fleet"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/managed"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// Sources of the signals a reconcile leaves behind.
+	SourceCondition    = "Condition"
+	SourceEvent        = "Event"
+	SourceStatusUpdate = "StatusUpdate"
+
+	// conditionSynced is the condition Crossplane uses to report whether the
+	// last reconcile succeeded.
+	conditionSynced = "Synced"
+
+	// maxBackoff is the longest Crossplane and providers wait before
+	// retrying a failed reconcile.
+	maxBackoff = time.Minute
+)
+
+// flags are the arguments that configure how often a controller reconciles
+// and their defaults.
+type flags struct {
+	poll, sync, rate          string
+	pollDef, syncDef, rateDef string
+}
+
+var (
+	// providerFlags are the flags of providers built with crossplane-runtime.
+	providerFlags = flags{poll: "poll", sync: "sync", rate: "max-reconcile-rate", pollDef: "1m", syncDef: "1h", rateDef: "10"}
+	// coreFlags are the flags of Crossplane itself.
+	coreFlags = flags{poll: "poll-interval", sync: "sync-interval", rate: "max-reconcile-rate", pollDef: "1m", syncDef: "1h", rateDef: "100"}
+)
+
+// Timing estimates when a resource was last reconciled and when it will be
+// reconciled next.
+type Timing struct {
+	Resource object.Summary `json:"resource"`
+	// Category of the resource, either managed or composite.
+	Category   string     `json:"category"`
+	Controller Controller `json:"controller"`
+	Generation int64      `json:"generation"`
+	// ObservedGeneration is the latest generation the controller reported
+	// reconciling, if it reports one.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// GenerationPending is set if the controller hasn't yet observed the
+	// latest change to the resource's spec.
+	GenerationPending bool `json:"generationPending"`
+	Paused            bool `json:"paused"`
+	// BackingOff is set if the last reconcile failed, in which case it is
+	// retried with exponential backoff rather than at the poll interval.
+	BackingOff bool `json:"backingOff"`
+	// LastReconcile is the most recent time a reconcile left a signal. Quiet
+	// reconciles that change nothing leave none, so it may have been later.
+	LastReconcile string   `json:"lastReconcile,omitempty"`
+	Signals       []Signal `json:"signals"`
+	// NextReconcileBy is the time the next reconcile is expected by, if one
+	// is expected at all.
+	NextReconcileBy string `json:"nextReconcileBy,omitempty"`
+	// Basis explains how the next reconcile was estimated.
+	Basis string   `json:"basis"`
+	Notes []string `json:"notes"`
+}
+
+// Controller is the controller that reconciles a resource and how often it
+// does so.
+type Controller struct {
+	// Name of the provider, or crossplane for composite resources.
+	Name string `json:"name"`
+	// Deployment running the controller, as namespace/name.
+	Deployment       string  `json:"deployment,omitempty"`
+	ReadyReplicas    int32   `json:"readyReplicas"`
+	PollInterval     Setting `json:"pollInterval"`
+	SyncInterval     Setting `json:"syncInterval"`
+	MaxReconcileRate Setting `json:"maxReconcileRate"`
+}
+
+// Setting is a reconcile setting read from a controller's arguments.
+type Setting struct {
+	Flag  string `json:"flag"`
+	Value string `json:"value,omitempty"`
+	// Default is set if the flag isn't passed, in which case the value is the
+	// controller's assumed default.
+	Default bool `json:"default"`
+}
+
+// Signal is a trace a reconcile left on a resource.
+type Signal struct {
+	Source string `json:"source"`
+	Time   string `json:"time"`
+	Detail string `json:"detail"`
+}
+
+// Estimator estimates the reconcile timing of resources in the configured
+// controlplane.
+type Estimator struct {
+	log logging.Logger
+	obj *object.Client
+	mr  *managed.Resources
+	now func() time.Time
+}
+
+// Option modifies the underlying Estimator.
+type Option func(*Estimator)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(e *Estimator) {
+		e.log = log
+	}
+}
+
+// WithClock overrides the clock used to estimate the next reconcile.
+func WithClock(now func() time.Time) Option {
+	return func(e *Estimator) {
+		e.now = now
+	}
+}
+
+// New constructs a new Estimator.
+func New(obj *object.Client, mr *managed.Resources, opts ...Option) *Estimator {
+	e := &Estimator{
+		log: logging.NewNopLogger(),
+		obj: obj,
+		mr:  mr,
+		now: time.Now,
+	}
+
+	for _, o := range opts {
+		o(e)
+	}
+
+	return e
+}
+
+// Estimate when the supplied managed or composite resource was last
+// reconciled, from its conditions, events and status updates, and when it
+// will be reconciled next, from its generation and the poll interval of the
+// controller that reconciles it.
+func (e *Estimator) Estimate(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Timing, error) {
+	c, err := crd.ForKind(ctx, e.obj, gvk)
+	if err != nil {
+		return nil, err
+	}
+	category := ""
+	for _, cat := range []string{fleet.CategoryManaged, fleet.CategoryComposite} {
+		if slices.Contains(c.Spec.Names.Categories, cat) {
+			category = cat
+		}
+	}
+	if category == "" {
+		return nil, errors.Errorf("%s is neither a managed nor a composite resource", gvk.Kind)
+	}
+
+	u, err := e.obj.Get(ctx, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	events, err := e.obj.Events(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Timing{
+		Resource:   object.Summarize(u),
+		Category:   category,
+		Generation: u.GetGeneration(),
+		Paused:     meta.IsPaused(u),
+		Signals:    []Signal{},
+		Notes:      []string{},
+	}
+	if category == fleet.CategoryManaged {
+		t.Controller = e.provider(ctx, gvk, t)
+	} else {
+		t.Controller = e.crossplane(ctx, t)
+	}
+
+	if og, ok := observedGeneration(u); ok {
+		t.ObservedGeneration = og
+		t.GenerationPending = og < t.Generation
+	} else {
+		t.Notes = append(t.Notes, "the resource doesn't report an observedGeneration, so pending spec changes can't be detected")
+	}
+
+	t.Signals = signals(t.Resource.Conditions, events, u.GetManagedFields())
+	last := time.Time{}
+	for _, s := range t.Signals {
+		if ts, err := time.Parse(time.RFC3339, s.Time); err == nil && ts.After(last) {
+			last = ts
+			t.LastReconcile = s.Time
+		}
+	}
+	t.BackingOff = failing(t.Resource.Conditions, events)
+
+	next(t, last, e.now())
+	return t, nil
+}
+
+// provider returns the provider that reconciles managed resources of the
+// supplied kind. Problems finding it are recorded as notes.
+func (e *Estimator) provider(ctx context.Context, gvk schema.GroupVersionKind, t *Timing) Controller {
+	p, err := e.mr.Provider(ctx, gvk)
+	if err != nil {
+		t.Notes = append(t.Notes, fmt.Sprintf("cannot find the provider of %s, so its defaults are assumed: %s", gvk.Kind, err))
+		return controller("", nil, providerFlags)
+	}
+	l, err := e.obj.Clientset().AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Notes = append(t.Notes, fmt.Sprintf("cannot list the Deployments of provider %s, so its defaults are assumed: %s", p.Name, err))
+		return controller(p.Name, nil, providerFlags)
+	}
+	for _, d := range l.Items {
+		if ref := metav1.GetControllerOf(&d); ref != nil && ref.Name == p.Revision {
+			return controller(p.Name, &d, providerFlags)
+		}
+	}
+	t.Notes = append(t.Notes, fmt.Sprintf("no Deployment found for revision %s of provider %s, so its defaults are assumed", p.Revision, p.Name))
+	return controller(p.Name, nil, providerFlags)
+}
+
+// crossplane returns Crossplane, which reconciles composite resources.
+// Problems finding it are recorded as notes.
+func (e *Estimator) crossplane(ctx context.Context, t *Timing) Controller {
+	l, err := e.obj.Clientset().AppsV1().Deployments("").List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{core.LabelApp: core.ComponentCore}).String(),
+	})
+	if err != nil {
+		t.Notes = append(t.Notes, fmt.Sprintf("cannot list the Crossplane Deployment, so its defaults are assumed: %s", err))
+		return controller(core.ComponentCore, nil, coreFlags)
+	}
+	if len(l.Items) == 0 {
+		t.Notes = append(t.Notes, "no Crossplane Deployment found, so its defaults are assumed")
+		return controller(core.ComponentCore, nil, coreFlags)
+	}
+	return controller(core.ComponentCore, &l.Items[0], coreFlags)
+}
+
+// controller reads the reconcile settings of the supplied Deployment, which
+// may be nil if it wasn't found.
+func controller(name string, d *appsv1.Deployment, f flags) Controller {
+	var args []string
+	c := Controller{Name: name}
+	if d != nil {
+		c.Deployment = d.GetNamespace() + "/" + d.GetName()
+		c.ReadyReplicas = d.Status.ReadyReplicas
+		if cs := d.Spec.Template.Spec.Containers; len(cs) > 0 {
+			args = cs[0].Args
+		}
+	}
+	c.PollInterval = setting(args, f.poll, f.pollDef)
+	c.SyncInterval = setting(args, f.sync, f.syncDef)
+	c.MaxReconcileRate = setting(args, f.rate, f.rateDef)
+	return c
+}
+
+// setting returns the value of the named flag in the supplied arguments, or
+// the supplied default if it isn't passed.
+func setting(args []string, name, def string) Setting {
+	if v, ok := Flag(args, name); ok {
+		return Setting{Flag: "--" + name, Value: v}
+	}
+	return Setting{Flag: "--" + name, Value: def, Default: true}
+}
+
+// Flag returns the value of the named flag in the supplied arguments, passed
+// either as --name=value or --name value.
+func Flag(args []string, name string) (string, bool) {
+	for i, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			continue
+		}
+		a = strings.TrimLeft(a, "-")
+		if v, ok := strings.CutPrefix(a, name+"="); ok {
+			return v, true
+		}
+		if a == name && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// observedGeneration returns the generation the controller last reported
+// reconciling, either in status.observedGeneration or the most recent of its
+// conditions' observedGeneration.
+func observedGeneration(u *unstructured.Unstructured) (int64, bool) {
+	p := fieldpath.Pave(u.Object)
+	if og, err := p.GetInteger("status.observedGeneration"); err == nil {
+		return og, true
+	}
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	out, found := int64(0), false
+	for _, c := range conditions {
+		m, _ := c.(map[string]any)
+		if og, ok, _ := unstructured.NestedInt64(m, "observedGeneration"); ok {
+			out, found = max(out, og), true
+		}
+	}
+	return out, found
+}
+
+// signals returns the most recent trace of a reconcile of each source: a
+// condition transition, an event and an update of the status subresource.
+func signals(conditions []object.Condition, events []object.Event, fields []metav1.ManagedFieldsEntry) []Signal {
+	out := []Signal{}
+
+	var cond *Signal
+	for _, c := range conditions {
+		if c.LastTransitionTime == "" || (cond != nil && c.LastTransitionTime <= cond.Time) {
+			continue
+		}
+		cond = &Signal{Source: SourceCondition, Time: c.LastTransitionTime, Detail: fmt.Sprintf("%s became %s", c.Type, c.Status)}
+		if c.Reason != "" {
+			cond.Detail += fmt.Sprintf(" (%s)", c.Reason)
+		}
+	}
+	if cond != nil {
+		out = append(out, *cond)
+	}
+
+	if len(events) > 0 {
+		ev := events[len(events)-1]
+		if ts := cmp.Or(ev.LastTimestamp, ev.EventTime); ts != "" {
+			out = append(out, Signal{Source: SourceEvent, Time: ts, Detail: fmt.Sprintf("%s event %s", cmp.Or(ev.Type, "Normal"), ev.Reason)})
+		}
+	}
+
+	var status *Signal
+	for _, f := range fields {
+		if f.Subresource != "status" || f.Time == nil {
+			continue
+		}
+		ts := object.FormatTime(f.Time.Time)
+		if status != nil && ts <= status.Time {
+			continue
+		}
+		status = &Signal{Source: SourceStatusUpdate, Time: ts, Detail: fmt.Sprintf("status updated by %s", f.Manager)}
+	}
+	if status != nil {
+		out = append(out, *status)
+	}
+	return out
+}
+
+// failing returns true if the supplied resource's last reconcile failed,
+// either because it isn't Synced or because its most recent event is a
+// warning.
+func failing(conditions []object.Condition, events []object.Event) bool {
+	if c, ok := object.FindCondition(conditions, conditionSynced); ok && c.Status == string(metav1.ConditionFalse) {
+		return true
+	}
+	return len(events) > 0 && events[len(events)-1].Type == "Warning"
+}
+
+// next estimates when the supplied resource will be reconciled next, given
+// its last known reconcile and the current time.
+func next(t *Timing, last, now time.Time) {
+	poll, err := time.ParseDuration(t.Controller.PollInterval.Value)
+	if err != nil {
+		t.Basis = fmt.Sprintf("cannot estimate the next reconcile, because poll interval %q isn't a duration", t.Controller.PollInterval.Value)
+		return
+	}
+
+	switch {
+	case t.Paused:
+		t.Basis = "the resource is paused, so it won't be reconciled until the crossplane.io/paused annotation is removed"
+	case t.Controller.Deployment != "" && t.Controller.ReadyReplicas == 0:
+		t.Basis = fmt.Sprintf("Deployment %s has no ready replicas, so the resource won't be reconciled until it's running again", t.Controller.Deployment)
+	case t.GenerationPending:
+		t.NextReconcileBy = object.FormatTime(now)
+		t.Basis = fmt.Sprintf("the spec changed (generation %d) since the controller last reported reconciling it (generation %d). Spec changes are reconciled immediately, so if this persists the controller is backlogged, limited by its max reconcile rate, or failing before it updates the status", t.Generation, t.ObservedGeneration)
+	case t.BackingOff:
+		by := now
+		if !last.IsZero() && last.Add(maxBackoff).After(now) {
+			by = last.Add(maxBackoff)
+		}
+		t.NextReconcileBy = object.FormatTime(by)
+		t.Basis = fmt.Sprintf("the last reconcile failed. Failed reconciles are retried with exponential backoff of up to %s rather than every poll interval", maxBackoff)
+	default:
+		by := now.Add(poll)
+		if !last.IsZero() && last.Add(poll).After(now) {
+			by = last.Add(poll)
+		}
+		t.NextReconcileBy = object.FormatTime(by)
+		t.Basis = fmt.Sprintf("healthy resources are reconciled every poll interval (%s) to detect drift. Reconciles that change nothing leave no signal, so the next one is due by %s at the latest", t.Controller.PollInterval.Value, t.NextReconcileBy)
+	}
+
+	if t.Controller.PollInterval.Default && t.Category == fleet.CategoryManaged {
+		t.Notes = append(t.Notes, "the provider doesn't set --poll, so the crossplane-runtime default of 1m is assumed; some providers, such as those generated by upjet, default to 10m")
+	}
+	t.Notes = append(t.Notes, fmt.Sprintf("every resource is also reconciled every sync interval (%s), and whenever it or a resource it watches changes", t.Controller.SyncInterval.Value))
+}
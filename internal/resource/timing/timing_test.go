@@ -0,0 +1,298 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package timing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/managed"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+var (
+	now       = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	bucketGVK = schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta1", Kind: "Bucket"}
+)
+
+func customResourceDefinition(plural string, gvk schema.GroupVersionKind, owner string, categories ...any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": plural + "." + gvk.Group},
+		"spec": map[string]any{
+			"group":    gvk.Group,
+			"names":    map[string]any{"kind": gvk.Kind, "plural": plural, "categories": categories},
+			"versions": []any{map[string]any{"name": gvk.Version, "served": true, "storage": true}},
+		},
+	}}
+	if owner != "" {
+		u.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "pkg.crossplane.io/v1", Kind: "ProviderRevision", Name: owner}})
+	}
+	return u
+}
+
+func providerRevision() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "ProviderRevision",
+		"metadata": map[string]any{
+			"name":   "provider-aws-s3-abc",
+			"labels": map[string]any{xpkg.LabelPackage: "provider-aws-s3"},
+		},
+		"spec": map[string]any{"desiredState": "Active"},
+	}}
+}
+
+func resource(gvk schema.GroupVersionKind, generation, observed int64, synced string, transitioned time.Time, annotations map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata":   map[string]any{"name": "my-resource", "generation": generation, "annotations": annotations},
+		"status": map[string]any{"conditions": []any{map[string]any{
+			"type":               "Synced",
+			"status":             synced,
+			"reason":             "ReconcileSuccess",
+			"lastTransitionTime": transitioned.Format(time.RFC3339),
+			"observedGeneration": observed,
+		}}},
+	}}
+}
+
+func providerDeployment(ready int32, args ...string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "crossplane-system",
+			Name:            "provider-aws-s3-abc",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ProviderRevision", Name: "provider-aws-s3-abc", Controller: ptr.To(true)}},
+		},
+		Spec:   appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Args: args}}}}},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: ready},
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	xrGVK := schema.GroupVersionKind{Group: "example.org", Version: "v1alpha1", Kind: "XDatabase"}
+	provider := Controller{
+		Name:             "provider-aws-s3",
+		Deployment:       "crossplane-system/provider-aws-s3-abc",
+		ReadyReplicas:    1,
+		PollInterval:     Setting{Flag: "--poll", Value: "5m"},
+		SyncInterval:     Setting{Flag: "--sync", Value: "1h", Default: true},
+		MaxReconcileRate: Setting{Flag: "--max-reconcile-rate", Value: "50"},
+	}
+
+	type want struct {
+		timing *Timing
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		gvk    schema.GroupVersionKind
+		objs   []*unstructured.Unstructured
+		typed  []runtime.Object
+		want   want
+	}{
+		"Polling": {
+			reason: "A healthy managed resource should next be reconciled a poll interval after its last reconcile.",
+			gvk:    bucketGVK,
+			objs: []*unstructured.Unstructured{
+				customResourceDefinition("buckets", bucketGVK, "provider-aws-s3-abc", "crossplane", "managed"),
+				providerRevision(),
+				resource(bucketGVK, 2, 2, "True", now.Add(-2*time.Minute), nil),
+			},
+			typed: []runtime.Object{providerDeployment(1, "--poll=5m", "--max-reconcile-rate", "50")},
+			want: want{timing: &Timing{
+				Category:           "managed",
+				Controller:         provider,
+				Generation:         2,
+				ObservedGeneration: 2,
+				LastReconcile:      "2025-01-01T11:58:00Z",
+				Signals:            []Signal{{Source: SourceCondition, Time: "2025-01-01T11:58:00Z", Detail: "Synced became True (ReconcileSuccess)"}},
+				NextReconcileBy:    "2025-01-01T12:03:00Z",
+			}},
+		},
+		"BackingOff": {
+			reason: "A managed resource whose last reconcile failed should be retried within the maximum backoff.",
+			gvk:    bucketGVK,
+			objs: []*unstructured.Unstructured{
+				customResourceDefinition("buckets", bucketGVK, "provider-aws-s3-abc", "managed"),
+				providerRevision(),
+				resource(bucketGVK, 2, 2, "False", now.Add(-10*time.Second), nil),
+			},
+			typed: []runtime.Object{providerDeployment(1, "--poll=5m", "--max-reconcile-rate", "50")},
+			want: want{timing: &Timing{
+				Category:           "managed",
+				Controller:         provider,
+				Generation:         2,
+				ObservedGeneration: 2,
+				BackingOff:         true,
+				LastReconcile:      "2025-01-01T11:59:50Z",
+				Signals:            []Signal{{Source: SourceCondition, Time: "2025-01-01T11:59:50Z", Detail: "Synced became False (ReconcileSuccess)"}},
+				NextReconcileBy:    "2025-01-01T12:00:50Z",
+			}},
+		},
+		"GenerationPending": {
+			reason: "A managed resource whose latest spec change wasn't observed should be reconciled immediately.",
+			gvk:    bucketGVK,
+			objs: []*unstructured.Unstructured{
+				customResourceDefinition("buckets", bucketGVK, "provider-aws-s3-abc", "managed"),
+				providerRevision(),
+				resource(bucketGVK, 3, 2, "True", now.Add(-2*time.Minute), nil),
+			},
+			typed: []runtime.Object{providerDeployment(1, "--poll=5m", "--max-reconcile-rate", "50")},
+			want: want{timing: &Timing{
+				Category:           "managed",
+				Controller:         provider,
+				Generation:         3,
+				ObservedGeneration: 2,
+				GenerationPending:  true,
+				LastReconcile:      "2025-01-01T11:58:00Z",
+				Signals:            []Signal{{Source: SourceCondition, Time: "2025-01-01T11:58:00Z", Detail: "Synced became True (ReconcileSuccess)"}},
+				NextReconcileBy:    "2025-01-01T12:00:00Z",
+			}},
+		},
+		"ProviderNotReady": {
+			reason: "No reconcile should be expected while the provider has no ready replicas.",
+			gvk:    bucketGVK,
+			objs: []*unstructured.Unstructured{
+				customResourceDefinition("buckets", bucketGVK, "provider-aws-s3-abc", "managed"),
+				providerRevision(),
+				resource(bucketGVK, 2, 2, "True", now.Add(-2*time.Minute), nil),
+			},
+			typed: []runtime.Object{providerDeployment(0, "--poll=5m", "--max-reconcile-rate", "50")},
+			want: want{timing: &Timing{
+				Category:           "managed",
+				Controller:         Controller{Name: provider.Name, Deployment: provider.Deployment, PollInterval: provider.PollInterval, SyncInterval: provider.SyncInterval, MaxReconcileRate: provider.MaxReconcileRate},
+				Generation:         2,
+				ObservedGeneration: 2,
+				LastReconcile:      "2025-01-01T11:58:00Z",
+				Signals:            []Signal{{Source: SourceCondition, Time: "2025-01-01T11:58:00Z", Detail: "Synced became True (ReconcileSuccess)"}},
+			}},
+		},
+		"PausedComposite": {
+			reason: "A paused composite resource shouldn't be reconciled, and Crossplane's defaults should be assumed if it isn't found.",
+			gvk:    xrGVK,
+			objs: []*unstructured.Unstructured{
+				customResourceDefinition("xdatabases", xrGVK, "", "composite"),
+				resource(xrGVK, 1, 1, "True", now.Add(-time.Hour), map[string]any{"crossplane.io/paused": "true"}),
+			},
+			want: want{timing: &Timing{
+				Category: "composite",
+				Controller: Controller{
+					Name:             "crossplane",
+					PollInterval:     Setting{Flag: "--poll-interval", Value: "1m", Default: true},
+					SyncInterval:     Setting{Flag: "--sync-interval", Value: "1h", Default: true},
+					MaxReconcileRate: Setting{Flag: "--max-reconcile-rate", Value: "100", Default: true},
+				},
+				Generation:         1,
+				ObservedGeneration: 1,
+				Paused:             true,
+				LastReconcile:      "2025-01-01T11:00:00Z",
+				Signals:            []Signal{{Source: SourceCondition, Time: "2025-01-01T11:00:00Z", Detail: "Synced became True (ReconcileSuccess)"}},
+			}},
+		},
+		"NotReconciled": {
+			reason: "An error should be returned for kinds that are neither managed nor composite resources.",
+			gvk:    xrGVK,
+			objs: []*unstructured.Unstructured{
+				customResourceDefinition("xdatabases", xrGVK, "", "claim"),
+				resource(xrGVK, 1, 1, "True", now, nil),
+			},
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cs := fake.NewClientset(tc.typed...)
+			obj := objectfake.NewClientWithClientset(cs, nil, tc.objs)
+			e := New(obj, managed.New(obj, xpkg.New(obj, pod.New(cs))), WithClock(func() time.Time { return now }))
+
+			got, err := e.Estimate(context.Background(), tc.gvk, types.NamespacedName{Name: "my-resource"})
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nEstimate(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.timing, got, cmpopts.IgnoreFields(Timing{}, "Resource", "Basis", "Notes")); diff != "" {
+				t.Errorf("\n%s\nEstimate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFlag(t *testing.T) {
+	type want struct {
+		value string
+		ok    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   []string
+		name   string
+		want   want
+	}{
+		"Equals": {
+			reason: "A flag passed as --name=value should be found.",
+			args:   []string{"--debug", "--poll=10m"},
+			name:   "poll",
+			want:   want{value: "10m", ok: true},
+		},
+		"Separate": {
+			reason: "A flag passed as --name value should be found.",
+			args:   []string{"--poll", "10m"},
+			name:   "poll",
+			want:   want{value: "10m", ok: true},
+		},
+		"Prefix": {
+			reason: "A flag whose name only starts with the supplied name shouldn't be found.",
+			args:   []string{"--poll-interval=10m"},
+			name:   "poll",
+		},
+		"Boolean": {
+			reason: "A flag followed by another flag has no value.",
+			args:   []string{"--poll", "--debug"},
+			name:   "poll",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, ok := Flag(tc.args, tc.name)
+			if diff := cmp.Diff(tc.want, want{value: v, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nFlag(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,474 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package xpkg
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+const (
+	// defaultRuntimeConfig is the DeploymentRuntimeConfig packages use when
+	// they don't reference one.
+	defaultRuntimeConfig = "default"
+
+	// endpointScheme prefixes the gRPC endpoint of a Function revision.
+	endpointScheme = "dns:///"
+
+	// maxRunFunctionErrors to return to the caller.
+	maxRunFunctionErrors = 10
+	// eventPageSize is the number of events requested per page when
+	// looking for errors running a Function.
+	eventPageSize = 500
+
+	// reasonComposeResources is the reason of the events composite resources
+	// emit when they fail to run their Composition's pipeline.
+	reasonComposeResources = "ComposeResources"
+)
+
+// tlsKeys are the keys a Function's TLS server Secret must contain.
+var tlsKeys = []string{"tls.crt", "tls.key", "ca.crt"}
+
+// RuntimeConfigGVK is the GroupVersionKind of a DeploymentRuntimeConfig.
+func RuntimeConfigGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1beta1", Kind: "DeploymentRuntimeConfig"}
+}
+
+// FunctionStatus is the runtime status of a Function package.
+type FunctionStatus struct {
+	// Function is the installation and health status of the package,
+	// including the logs and events of its runtime pods.
+	Function Status `json:"function"`
+	// Endpoint is the gRPC endpoint Crossplane calls the Function at.
+	Endpoint      string         `json:"endpoint,omitempty"`
+	Deployment    *Deployment    `json:"deployment,omitempty"`
+	Service       *Service       `json:"service,omitempty"`
+	TLS           *TLSSecret     `json:"tls,omitempty"`
+	RuntimeConfig *RuntimeConfig `json:"runtimeConfig,omitempty"`
+	// RunFunctionErrors are recent warning events of composite resources
+	// and other resources that failed to run the Function, most recent
+	// first.
+	RunFunctionErrors []RunFunctionError `json:"runFunctionErrors"`
+	// Problems found with the Function's runtime, if any.
+	Problems []string `json:"problems"`
+}
+
+// Deployment is the Deployment running a package revision.
+type Deployment struct {
+	Namespace         string             `json:"namespace"`
+	Name              string             `json:"name"`
+	Replicas          int32              `json:"replicas"`
+	ReadyReplicas     int32              `json:"readyReplicas"`
+	AvailableReplicas int32              `json:"availableReplicas"`
+	Image             string             `json:"image,omitempty"`
+	Conditions        []object.Condition `json:"conditions,omitempty"`
+}
+
+// Service is the Service exposing a Function's gRPC endpoint.
+type Service struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Port      int32  `json:"port,omitempty"`
+	Exists    bool   `json:"exists"`
+	ClusterIP string `json:"clusterIP,omitempty"`
+	// ReadyEndpoints is the number of endpoints ready to serve requests.
+	ReadyEndpoints    int    `json:"readyEndpoints"`
+	NotReadyEndpoints int    `json:"notReadyEndpoints"`
+	Error             string `json:"error,omitempty"`
+}
+
+// TLSSecret is the Secret holding a Function's TLS server certificate. Its
+// values are never read into the result.
+type TLSSecret struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Exists    bool     `json:"exists"`
+	Keys      []string `json:"keys"`
+	// Skipped explains why the Secret wasn't checked.
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RuntimeConfig is the DeploymentRuntimeConfig used by a package revision.
+type RuntimeConfig struct {
+	Name   string `json:"name"`
+	Exists bool   `json:"exists"`
+	// Applied is whether the runtime Deployment reflects the
+	// DeploymentRuntimeConfig's deployment template.
+	Applied     bool     `json:"applied"`
+	Differences []string `json:"differences,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// RunFunctionError is a warning event of a resource that failed to run a
+// Function.
+type RunFunctionError struct {
+	Resource object.Reference `json:"resource"`
+	Event    object.Event     `json:"event"`
+}
+
+// FunctionStatus returns the runtime status of the named Function: its
+// Deployment, the Service and TLS Secret of its gRPC endpoint, its
+// DeploymentRuntimeConfig and the errors resources reported running it.
+func (p *Packages) FunctionStatus(ctx context.Context, name string) (*FunctionStatus, error) {
+	st, err := p.Status(ctx, KindFunction, name)
+	if err != nil {
+		return nil, err
+	}
+	fs := &FunctionStatus{Function: *st, Problems: []string{}}
+	if c, ok := object.FindCondition(st.Conditions, "Healthy"); ok && c.Status != string(metav1.ConditionTrue) {
+		fs.Problems = append(fs.Problems, fmt.Sprintf("Function %s is not Healthy: %s", name, cmp.Or(c.Message, c.Reason)))
+	}
+	if fs.RunFunctionErrors, err = p.runFunctionErrors(ctx, name); err != nil {
+		return nil, err
+	}
+	if n := len(fs.RunFunctionErrors); n > 0 {
+		fs.Problems = append(fs.Problems, fmt.Sprintf("%d recent events report errors running Function %s", n, name))
+	}
+
+	if st.DesiredRevision == "" {
+		fs.Problems = append(fs.Problems, fmt.Sprintf("Function %s has no active revision", name))
+		return fs, nil
+	}
+	rev, err := p.obj.Get(ctx, RevisionGVK(KindFunction), types.NamespacedName{Name: st.DesiredRevision})
+	if err != nil {
+		return nil, err
+	}
+	pv := fieldpath.Pave(rev.Object)
+	fs.Endpoint, _ = pv.GetString("status.endpoint")
+
+	// Package runtimes run in Crossplane's namespace, which is also where
+	// their Service and TLS Secret live.
+	var ns string
+	if len(st.Pods) > 0 {
+		ns = st.Pods[0].Namespace
+	}
+
+	if fs.Deployment, err = p.deployment(ctx, ns, st.DesiredRevision); err != nil {
+		return nil, err
+	}
+	if fs.Deployment == nil {
+		fs.Problems = append(fs.Problems, fmt.Sprintf("no Deployment runs revision %s", st.DesiredRevision))
+	} else {
+		ns = fs.Deployment.Namespace
+		if fs.Deployment.ReadyReplicas < fs.Deployment.Replicas {
+			fs.Problems = append(fs.Problems, fmt.Sprintf("Deployment %s/%s has %d of %d replicas ready", ns, fs.Deployment.Name, fs.Deployment.ReadyReplicas, fs.Deployment.Replicas))
+		}
+	}
+
+	if fs.Endpoint == "" {
+		fs.Problems = append(fs.Problems, fmt.Sprintf("revision %s has no gRPC endpoint", st.DesiredRevision))
+	} else {
+		fs.Service = p.service(ctx, fs.Endpoint)
+		switch {
+		case fs.Service.Error != "":
+			fs.Problems = append(fs.Problems, fmt.Sprintf("cannot check endpoint %s: %s", fs.Endpoint, fs.Service.Error))
+		case !fs.Service.Exists:
+			fs.Problems = append(fs.Problems, fmt.Sprintf("Service %s/%s of endpoint %s does not exist", fs.Service.Namespace, fs.Service.Name, fs.Endpoint))
+		case fs.Service.ReadyEndpoints == 0:
+			fs.Problems = append(fs.Problems, fmt.Sprintf("Service %s/%s has no ready endpoints", fs.Service.Namespace, fs.Service.Name))
+		}
+		ns = cmp.Or(ns, fs.Service.Namespace)
+	}
+
+	if secret, _ := pv.GetString("spec.tlsServerSecretName"); secret != "" && ns != "" {
+		fs.TLS = p.tlsSecret(ctx, types.NamespacedName{Namespace: ns, Name: secret})
+		switch {
+		case fs.TLS.Skipped != "":
+			// Not a problem with the Secret; the server can't read Secrets.
+		case fs.TLS.Error != "":
+			fs.Problems = append(fs.Problems, fmt.Sprintf("cannot read TLS Secret %s/%s: %s", ns, secret, fs.TLS.Error))
+		case !fs.TLS.Exists:
+			fs.Problems = append(fs.Problems, fmt.Sprintf("TLS Secret %s/%s does not exist", ns, secret))
+		default:
+			for _, k := range tlsKeys {
+				if !slices.Contains(fs.TLS.Keys, k) {
+					fs.Problems = append(fs.Problems, fmt.Sprintf("TLS Secret %s/%s has no %s key", ns, secret, k))
+				}
+			}
+		}
+	}
+
+	rc, _ := pv.GetString("spec.runtimeConfigRef.name")
+	fs.RuntimeConfig = p.runtimeConfig(ctx, cmp.Or(rc, defaultRuntimeConfig), fs.Deployment)
+	switch {
+	case fs.RuntimeConfig.Error != "":
+		fs.Problems = append(fs.Problems, fmt.Sprintf("cannot read DeploymentRuntimeConfig %s: %s", fs.RuntimeConfig.Name, fs.RuntimeConfig.Error))
+	case !fs.RuntimeConfig.Exists:
+		fs.Problems = append(fs.Problems, fmt.Sprintf("DeploymentRuntimeConfig %s does not exist", fs.RuntimeConfig.Name))
+	case fs.Deployment != nil && !fs.RuntimeConfig.Applied:
+		fs.Problems = append(fs.Problems, fmt.Sprintf("Deployment %s/%s does not reflect DeploymentRuntimeConfig %s", fs.Deployment.Namespace, fs.Deployment.Name, fs.RuntimeConfig.Name))
+	}
+
+	return fs, nil
+}
+
+// deployment returns the Deployment controlled by the supplied revision, if
+// any. An empty namespace searches every namespace.
+func (p *Packages) deployment(ctx context.Context, ns, revision string) (*Deployment, error) {
+	l, err := p.obj.Clientset().AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range l.Items {
+		if ref := metav1.GetControllerOf(&d); ref == nil || ref.Name != revision {
+			continue
+		}
+		out := &Deployment{
+			Namespace:         d.GetNamespace(),
+			Name:              d.GetName(),
+			Replicas:          ptr.Deref(d.Spec.Replicas, 1),
+			ReadyReplicas:     d.Status.ReadyReplicas,
+			AvailableReplicas: d.Status.AvailableReplicas,
+			Conditions:        []object.Condition{},
+		}
+		if c := runtimeContainerOf(d.Spec.Template.Spec.Containers); c != nil {
+			out.Image = c.Image
+		}
+		for _, c := range d.Status.Conditions {
+			out.Conditions = append(out.Conditions, object.Condition{
+				Type:               string(c.Type),
+				Status:             string(c.Status),
+				Reason:             c.Reason,
+				Message:            c.Message,
+				LastTransitionTime: object.FormatTime(c.LastTransitionTime.Time),
+			})
+		}
+		return out, nil
+	}
+	return nil, nil
+}
+
+// service returns the Service of the supplied gRPC endpoint, which has the
+// form dns:///name.namespace:port, and counts its endpoints.
+func (p *Packages) service(ctx context.Context, endpoint string) *Service {
+	s := &Service{}
+	hostport, ok := strings.CutPrefix(endpoint, endpointScheme)
+	if !ok {
+		s.Error = fmt.Sprintf("endpoint is not of the form %sname.namespace:port", endpointScheme)
+		return s
+	}
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	if n, err := strconv.ParseInt(port, 10, 32); err == nil {
+		s.Port = int32(n)
+	}
+	s.Name, s.Namespace, _ = strings.Cut(host, ".")
+	s.Namespace, _, _ = strings.Cut(s.Namespace, ".")
+
+	svc, err := p.obj.Clientset().CoreV1().Services(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		return s
+	case err != nil:
+		s.Error = err.Error()
+		return s
+	}
+	s.Exists = true
+	s.ClusterIP = svc.Spec.ClusterIP
+
+	eps, err := p.obj.Clientset().DiscoveryV1().EndpointSlices(s.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: s.Name}).String(),
+	})
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	for _, es := range eps.Items {
+		for _, e := range es.Endpoints {
+			if e.Conditions.Ready == nil || *e.Conditions.Ready {
+				s.ReadyEndpoints++
+				continue
+			}
+			s.NotReadyEndpoints++
+		}
+	}
+	return s
+}
+
+// tlsSecret describes the supplied TLS Secret without reading its values.
+func (p *Packages) tlsSecret(ctx context.Context, nn types.NamespacedName) *TLSSecret {
+	t := &TLSSecret{Namespace: nn.Namespace, Name: nn.Name, Keys: []string{}}
+	sec, err := p.obj.Clientset().CoreV1().Secrets(nn.Namespace).Get(ctx, nn.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		return t
+	case object.SecretSkipped(err) != "":
+		t.Skipped = object.SecretSkipped(err)
+		return t
+	case err != nil:
+		t.Error = err.Error()
+		return t
+	}
+	t.Exists = true
+	for k := range sec.Data {
+		t.Keys = append(t.Keys, k)
+	}
+	slices.Sort(t.Keys)
+	return t
+}
+
+// runtimeConfig describes the named DeploymentRuntimeConfig and whether the
+// supplied Deployment reflects its deployment template.
+func (p *Packages) runtimeConfig(ctx context.Context, name string, d *Deployment) *RuntimeConfig {
+	rc := &RuntimeConfig{Name: name}
+	u, err := p.obj.Get(ctx, RuntimeConfigGVK(), types.NamespacedName{Name: name})
+	switch {
+	case kerrors.IsNotFound(err):
+		return rc
+	case err != nil:
+		rc.Error = err.Error()
+		return rc
+	}
+	rc.Exists = true
+	if d == nil {
+		return rc
+	}
+
+	dep, err := p.obj.Clientset().AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		rc.Error = err.Error()
+		return rc
+	}
+	var tmpl appsv1.DeploymentSpec
+	_ = fieldpath.Pave(u.Object).GetValueInto("spec.deploymentTemplate.spec", &tmpl)
+	rc.Differences = templateDifferences(tmpl, dep.Spec)
+	rc.Applied = len(rc.Differences) == 0
+	return rc
+}
+
+// templateDifferences returns how the supplied Deployment differs from the
+// supplied deployment template of a DeploymentRuntimeConfig. Only the fields
+// commonly set by a template are compared.
+func templateDifferences(tmpl, actual appsv1.DeploymentSpec) []string {
+	var out []string
+	if tmpl.Replicas != nil && *tmpl.Replicas != ptr.Deref(actual.Replicas, 1) {
+		out = append(out, fmt.Sprintf("replicas is %d, want %d", ptr.Deref(actual.Replicas, 1), *tmpl.Replicas))
+	}
+	if sa := tmpl.Template.Spec.ServiceAccountName; sa != "" && sa != actual.Template.Spec.ServiceAccountName {
+		out = append(out, fmt.Sprintf("serviceAccountName is %q, want %q", actual.Template.Spec.ServiceAccountName, sa))
+	}
+	for _, want := range tmpl.Template.Spec.Containers {
+		i := slices.IndexFunc(actual.Template.Spec.Containers, func(c corev1.Container) bool { return c.Name == want.Name })
+		if i < 0 {
+			out = append(out, fmt.Sprintf("container %s is missing", want.Name))
+			continue
+		}
+		got := actual.Template.Spec.Containers[i]
+		if want.Image != "" && want.Image != got.Image {
+			out = append(out, fmt.Sprintf("container %s image is %q, want %q", want.Name, got.Image, want.Image))
+		}
+		for _, a := range want.Args {
+			if !slices.Contains(got.Args, a) {
+				out = append(out, fmt.Sprintf("container %s is missing arg %q", want.Name, a))
+			}
+		}
+		for _, e := range want.Env {
+			if !slices.ContainsFunc(got.Env, func(g corev1.EnvVar) bool { return g.Name == e.Name }) {
+				out = append(out, fmt.Sprintf("container %s is missing env var %s", want.Name, e.Name))
+			}
+		}
+	}
+	return out
+}
+
+// runFunctionErrors returns the most recent warning events that report an
+// error running the named Function. Only the events composite resources emit
+// when they fail to compose are read, a page at a time. Crossplane quotes the
+// Function's name in these events, for example: cannot run Function
+// "function-x": ...
+func (p *Packages) runFunctionErrors(ctx context.Context, name string) ([]RunFunctionError, error) {
+	opts := metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("type", corev1.EventTypeWarning),
+			fields.OneTermEqualSelector("reason", reasonComposeResources),
+		).String(),
+		Limit: eventPageSize,
+	}
+
+	quoted := strings.ToLower(fmt.Sprintf("Function %q", name))
+	items := []corev1.Event{}
+	for {
+		l, err := p.obj.Clientset().CoreV1().Events("").List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range l.Items {
+			if e.Type == corev1.EventTypeWarning && e.Reason == reasonComposeResources && strings.Contains(strings.ToLower(e.Message), quoted) {
+				items = append(items, e)
+			}
+		}
+		if l.Continue == "" {
+			break
+		}
+		opts.Continue = l.Continue
+	}
+	slices.SortStableFunc(items, func(a, b corev1.Event) int {
+		return object.LastSeen(b).Compare(object.LastSeen(a))
+	})
+
+	out := []RunFunctionError{}
+	for _, e := range items[:min(len(items), maxRunFunctionErrors)] {
+		out = append(out, RunFunctionError{
+			Resource: object.Reference{
+				APIVersion: e.InvolvedObject.APIVersion,
+				Kind:       e.InvolvedObject.Kind,
+				Namespace:  e.InvolvedObject.Namespace,
+				Name:       e.InvolvedObject.Name,
+			},
+			Event: object.ConvertEvent(e),
+		})
+	}
+	return out, nil
+}
+
+// runtimeContainerOf returns the container running the package, or the
+// first container if none is named after the runtime.
+func runtimeContainerOf(cs []corev1.Container) *corev1.Container {
+	for i := range cs {
+		if cs[i].Name == RuntimeContainer {
+			return &cs[i]
+		}
+	}
+	if len(cs) > 0 {
+		return &cs[0]
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package xpkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+)
+
+func function() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "Function",
+		"metadata":   map[string]any{"name": "function-go-templating"},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Healthy", "status": "True", "reason": "HealthyPackageRevision"},
+			},
+		},
+	}}
+}
+
+func functionRevision() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "FunctionRevision",
+		"metadata": map[string]any{
+			"name":   "function-go-templating-abc",
+			"labels": map[string]any{LabelPackage: "function-go-templating"},
+		},
+		"spec": map[string]any{
+			"desiredState":        "Active",
+			"tlsServerSecretName": "function-go-templating-tls-server",
+			"runtimeConfigRef":    map[string]any{"name": "debug"},
+			"image":               "xpkg.upbound.io/crossplane-contrib/function-go-templating:v0.9.0",
+		},
+		"status": map[string]any{"endpoint": "dns:///function-go-templating.crossplane-system:9443"},
+	}}
+}
+
+func runtimeConfig() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1beta1",
+		"kind":       "DeploymentRuntimeConfig",
+		"metadata":   map[string]any{"name": "debug"},
+		"spec": map[string]any{
+			"deploymentTemplate": map[string]any{
+				"spec": map[string]any{
+					"selector": map[string]any{},
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{
+								map[string]any{"name": RuntimeContainer, "args": []any{"--debug"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func functionDeployment(args ...string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "crossplane-system",
+			Name:      "function-go-templating-abc",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "pkg.crossplane.io/v1",
+				Kind:       "FunctionRevision",
+				Name:       "function-go-templating-abc",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  RuntimeContainer,
+				Image: "xpkg.upbound.io/crossplane-contrib/function-go-templating:v0.9.0",
+				Args:  args,
+			}}}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func TestFunctionStatus(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "function-go-templating"},
+		Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.10"},
+	}
+	eps := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "crossplane-system",
+			Name:      "function-go-templating-xyz",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "function-go-templating"},
+		},
+		Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}}},
+	}
+	tls := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "function-go-templating-tls-server"},
+		Data:       map[string][]byte{"tls.crt": []byte("c"), "tls.key": []byte("k")},
+	}
+	runErr := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "xdb.123"},
+		InvolvedObject: corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "XDatabase", Name: "my-db"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "ComposeResources",
+		Message:        `cannot compose resources: cannot run Composition pipeline step "render": cannot run Function "function-go-templating": rpc error: code = Unavailable`,
+	}
+	otherErr := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "crossplane-system", Name: "pkg.456"},
+		InvolvedObject: corev1.ObjectReference{APIVersion: "pkg.crossplane.io/v1", Kind: "Function", Name: "function-go-templating"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "SyncPackage",
+		Message:        `cannot sync Function "function-go-templating"`,
+	}
+
+	cases := map[string]struct {
+		reason string
+		objs   []*unstructured.Unstructured
+		typed  []runtime.Object
+		want   *FunctionStatus
+	}{
+		"Healthy": {
+			reason: "A Function whose runtime reflects its DeploymentRuntimeConfig and serves its endpoint should only report its missing TLS key.",
+			objs:   []*unstructured.Unstructured{function(), functionRevision(), runtimeConfig()},
+			typed:  []runtime.Object{functionDeployment("--debug"), svc, eps, tls},
+			want: &FunctionStatus{
+				Endpoint: "dns:///function-go-templating.crossplane-system:9443",
+				Deployment: &Deployment{
+					Namespace: "crossplane-system", Name: "function-go-templating-abc",
+					Replicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+					Image:      "xpkg.upbound.io/crossplane-contrib/function-go-templating:v0.9.0",
+					Conditions: []object.Condition{},
+				},
+				Service: &Service{
+					Namespace: "crossplane-system", Name: "function-go-templating", Port: 9443,
+					Exists: true, ClusterIP: "10.0.0.10", ReadyEndpoints: 1,
+				},
+				TLS:               &TLSSecret{Namespace: "crossplane-system", Name: "function-go-templating-tls-server", Exists: true, Keys: []string{"tls.crt", "tls.key"}},
+				RuntimeConfig:     &RuntimeConfig{Name: "debug", Exists: true, Applied: true},
+				RunFunctionErrors: []RunFunctionError{},
+				Problems:          []string{"TLS Secret crossplane-system/function-go-templating-tls-server has no ca.crt key"},
+			},
+		},
+		"Broken": {
+			reason: "A Function without a Service, TLS Secret or applied DeploymentRuntimeConfig should report each problem and the errors composite resources hit running it.",
+			objs:   []*unstructured.Unstructured{function(), functionRevision(), runtimeConfig()},
+			typed:  []runtime.Object{functionDeployment(), runErr, otherErr},
+			want: &FunctionStatus{
+				Endpoint: "dns:///function-go-templating.crossplane-system:9443",
+				Deployment: &Deployment{
+					Namespace: "crossplane-system", Name: "function-go-templating-abc",
+					Replicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+					Image:      "xpkg.upbound.io/crossplane-contrib/function-go-templating:v0.9.0",
+					Conditions: []object.Condition{},
+				},
+				Service: &Service{Namespace: "crossplane-system", Name: "function-go-templating", Port: 9443},
+				TLS:     &TLSSecret{Namespace: "crossplane-system", Name: "function-go-templating-tls-server", Keys: []string{}},
+				RuntimeConfig: &RuntimeConfig{
+					Name: "debug", Exists: true,
+					Differences: []string{`container package-runtime is missing arg "--debug"`},
+				},
+				RunFunctionErrors: []RunFunctionError{{
+					Resource: object.Reference{APIVersion: "example.org/v1", Kind: "XDatabase", Name: "my-db"},
+				}},
+				Problems: []string{
+					"1 recent events report errors running Function function-go-templating",
+					"Service crossplane-system/function-go-templating of endpoint dns:///function-go-templating.crossplane-system:9443 does not exist",
+					"TLS Secret crossplane-system/function-go-templating-tls-server does not exist",
+					"Deployment crossplane-system/function-go-templating-abc does not reflect DeploymentRuntimeConfig debug",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cs := fake.NewClientset(tc.typed...)
+			p := New(objectfake.NewClientWithClientset(cs, nil, tc.objs), pod.New(cs))
+
+			got, err := p.FunctionStatus(context.Background(), "function-go-templating")
+			if err != nil {
+				t.Fatalf("\n%s\nFunctionStatus(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(FunctionStatus{}, "Function"), cmpopts.IgnoreFields(RunFunctionError{}, "Event")); diff != "" {
+				t.Errorf("\n%s\nFunctionStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
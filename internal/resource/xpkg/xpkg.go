@@ -0,0 +1,398 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package xpkg provides tool helpers for working with Crossplane packages and
+their revisions.
+*/
+package xpkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+)
+
+const (
+	// Group of the Crossplane package APIs.
+	Group = "pkg.crossplane.io"
+
+	// KindProvider is the kind of a Crossplane Provider package.
+	KindProvider = "Provider"
+	// KindFunction is the kind of a Crossplane Function package.
+	KindFunction = "Function"
+	// KindConfiguration is the kind of a Crossplane Configuration package.
+	KindConfiguration = "Configuration"
+
+	// LabelPackage is the label revisions use to refer to their package.
+	LabelPackage = "pkg.crossplane.io/package"
+	// LabelRevision is the label runtime pods use to refer to their
+	// revision.
+	LabelRevision = "pkg.crossplane.io/revision"
+	// RuntimeContainer is the name of the container running a package in
+	// its runtime pods.
+	RuntimeContainer = "package-runtime"
+
+	// lockName is the name of the singleton package Lock.
+	lockName = "lock"
+	// desiredStateActive is the desired state of the active revision.
+	desiredStateActive = "Active"
+)
+
+// Kinds returns the supported package kinds.
+func Kinds() []string {
+	return []string{KindProvider, KindFunction, KindConfiguration}
+}
+
+// GVK returns the GroupVersionKind of the supplied package kind.
+func GVK(kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1", Kind: kind}
+}
+
+// RevisionGVK returns the GroupVersionKind of the revisions of the supplied
+// package kind.
+func RevisionGVK(kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1", Kind: kind + "Revision"}
+}
+
+// lockGVK is the GroupVersionKind of the package Lock.
+func lockGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: Group, Version: "v1beta1", Kind: "Lock"}
+}
+
+// Status is the installation and health status of a package.
+type Status struct {
+	object.Summary
+
+	Package           string `json:"package"`
+	CurrentRevision   string `json:"currentRevision,omitempty"`
+	CurrentIdentifier string `json:"currentIdentifier,omitempty"`
+	// DesiredRevision is the revision whose desired state is Active.
+	DesiredRevision string `json:"desiredRevision,omitempty"`
+	// ImageDigest of the running package, if it could be determined.
+	ImageDigest string `json:"imageDigest,omitempty"`
+
+	Revisions        []Revision     `json:"revisions"`
+	Dependencies     []Dependency   `json:"dependencies,omitempty"`
+	DependencyErrors []string       `json:"dependencyErrors,omitempty"`
+	Pods             []RuntimePod   `json:"pods,omitempty"`
+	Events           []object.Event `json:"events"`
+}
+
+// Revision is a summary of a package revision.
+type Revision struct {
+	Name                  string             `json:"name"`
+	Revision              int64              `json:"revision"`
+	DesiredState          string             `json:"desiredState"`
+	Image                 string             `json:"image"`
+	Conditions            []object.Condition `json:"conditions,omitempty"`
+	FoundDependencies     int64              `json:"foundDependencies,omitempty"`
+	InstalledDependencies int64              `json:"installedDependencies,omitempty"`
+	InvalidDependencies   int64              `json:"invalidDependencies,omitempty"`
+}
+
+// Dependency is a dependency of a package as recorded in the Lock.
+type Dependency struct {
+	Package     string `json:"package"`
+	Constraints string `json:"constraints,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Resolved    bool   `json:"resolved"`
+}
+
+// RuntimePod is a pod running a package revision.
+type RuntimePod struct {
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace"`
+	Phase     string         `json:"phase"`
+	Image     string         `json:"image,omitempty"`
+	ImageID   string         `json:"imageID,omitempty"`
+	Logs      []string       `json:"logs"`
+	LogsError string         `json:"logsError,omitempty"`
+	Events    []object.Event `json:"events"`
+}
+
+// Packages provides methods for deriving details for Crossplane packages in
+// the configured controlplane.
+type Packages struct {
+	log logging.Logger
+	obj *object.Client
+	pod *pod.Pod
+}
+
+// Option modifies the underlying Packages.
+type Option func(*Packages)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(p *Packages) {
+		p.log = log
+	}
+}
+
+// New constructs a new Packages.
+func New(obj *object.Client, p *pod.Pod, opts ...Option) *Packages {
+	pk := &Packages{
+		log: logging.NewNopLogger(),
+		obj: obj,
+		pod: p,
+	}
+
+	for _, o := range opts {
+		o(pk)
+	}
+
+	return pk
+}
+
+// Status returns the installation and health status of the package of the
+// supplied kind and name.
+func (p *Packages) Status(ctx context.Context, kind, name string) (*Status, error) {
+	u, err := p.obj.Get(ctx, GVK(kind), types.NamespacedName{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	pv := fieldpath.Pave(u.Object)
+	s := &Status{Summary: object.Summarize(u)}
+	s.Package, _ = pv.GetString("spec.package")
+	s.CurrentRevision, _ = pv.GetString("status.currentRevision")
+	s.CurrentIdentifier, _ = pv.GetString("status.currentIdentifier")
+
+	revs, err := p.Revisions(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	s.Revisions = revs
+
+	var active *Revision
+	for i := range revs {
+		if revs[i].DesiredState == desiredStateActive {
+			active = &revs[i]
+			s.DesiredRevision = active.Name
+		}
+	}
+
+	if active != nil {
+		s.Dependencies, s.DependencyErrors = p.dependencies(ctx, active)
+		if _, digest, ok := strings.Cut(active.Image, "@"); ok {
+			s.ImageDigest = digest
+		}
+
+		// Configurations don't have a runtime.
+		if kind != KindConfiguration {
+			pods, err := p.RuntimePods(ctx, active.Name)
+			if err != nil {
+				return nil, err
+			}
+			s.Pods = pods
+			for _, rp := range pods {
+				if _, digest, ok := strings.Cut(rp.ImageID, "@"); ok && s.ImageDigest == "" {
+					s.ImageDigest = digest
+				}
+			}
+		}
+	}
+
+	if s.Events, err = p.obj.Events(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Revisions returns the revisions of the package of the supplied kind and
+// name.
+func (p *Packages) Revisions(ctx context.Context, kind, name string) ([]Revision, error) {
+	l, err := p.obj.List(ctx, RevisionGVK(kind), "", metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{LabelPackage: name}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revs := make([]Revision, 0, len(l))
+	for i := range l {
+		revs = append(revs, revision(&l[i]))
+	}
+	return revs, nil
+}
+
+// ActiveRevision returns the name of the active revision of the package of
+// the supplied kind and name.
+func (p *Packages) ActiveRevision(ctx context.Context, kind, name string) (string, error) {
+	revs, err := p.Revisions(ctx, kind, name)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range revs {
+		if r.DesiredState == desiredStateActive {
+			return r.Name, nil
+		}
+	}
+	return "", errors.Errorf("%s %s has no active revision", kind, name)
+}
+
+// revision summarizes the supplied package revision.
+func revision(u *unstructured.Unstructured) Revision {
+	pv := fieldpath.Pave(u.Object)
+	r := Revision{
+		Name:       u.GetName(),
+		Conditions: object.Conditions(u),
+	}
+	r.Revision, _ = pv.GetInteger("spec.revision")
+	r.DesiredState, _ = pv.GetString("spec.desiredState")
+	r.Image, _ = pv.GetString("spec.image")
+	r.FoundDependencies, _ = pv.GetInteger("status.foundDependencies")
+	r.InstalledDependencies, _ = pv.GetInteger("status.installedDependencies")
+	r.InvalidDependencies, _ = pv.GetInteger("status.invalidDependencies")
+	return r
+}
+
+// lockPackage is a package recorded in the Lock.
+type lockPackage struct {
+	Name         string           `json:"name"`
+	Source       string           `json:"source"`
+	Dependencies []lockDependency `json:"dependencies"`
+}
+
+// lockDependency is a dependency of a package recorded in the Lock.
+type lockDependency struct {
+	Package     string `json:"package"`
+	Constraints string `json:"constraints"`
+	Type        string `json:"type"`
+	Kind        string `json:"kind"`
+}
+
+// dependencies returns the dependencies of the supplied revision as recorded
+// in the Lock, along with any errors resolving them.
+func (p *Packages) dependencies(ctx context.Context, rev *Revision) ([]Dependency, []string) {
+	var errs []string
+	if rev.InvalidDependencies > 0 {
+		errs = append(errs, fmt.Sprintf("%d dependencies have versions that do not satisfy their constraints", rev.InvalidDependencies))
+	}
+	if missing := rev.FoundDependencies - rev.InstalledDependencies; missing > 0 {
+		errs = append(errs, fmt.Sprintf("%d of %d dependencies are not installed", missing, rev.FoundDependencies))
+	}
+
+	lock, err := p.obj.Get(ctx, lockGVK(), types.NamespacedName{Name: lockName})
+	if kerrors.IsNotFound(errors.Cause(err)) {
+		return nil, errs
+	}
+	if err != nil {
+		return nil, append(errs, err.Error())
+	}
+
+	var pkgs []lockPackage
+	if err := fieldpath.Pave(lock.Object).GetValueInto("packages", &pkgs); err != nil {
+		return nil, append(errs, errors.Wrap(err, "cannot read packages from the lock").Error())
+	}
+
+	sources := map[string]bool{}
+	var self *lockPackage
+	for i := range pkgs {
+		sources[pkgs[i].Source] = true
+		if pkgs[i].Name == rev.Name {
+			self = &pkgs[i]
+		}
+	}
+	if self == nil {
+		return nil, append(errs, fmt.Sprintf("revision %s is not recorded in the lock", rev.Name))
+	}
+
+	deps := make([]Dependency, 0, len(self.Dependencies))
+	for _, d := range self.Dependencies {
+		t := d.Type
+		if t == "" {
+			t = d.Kind
+		}
+		dep := Dependency{
+			Package:     d.Package,
+			Constraints: d.Constraints,
+			Type:        t,
+			Resolved:    sources[d.Package],
+		}
+		if !dep.Resolved {
+			errs = append(errs, fmt.Sprintf("dependency %s (%s) is not present in the lock", d.Package, d.Constraints))
+		}
+		deps = append(deps, dep)
+	}
+	return deps, errs
+}
+
+// RuntimePods returns the pods running the supplied package revision along
+// with their recent logs and events.
+func (p *Packages) RuntimePods(ctx context.Context, revision string) ([]RuntimePod, error) {
+	l, err := p.obj.Clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{LabelRevision: revision}).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods for revision %s", revision)
+	}
+
+	pods := make([]RuntimePod, 0, len(l.Items))
+	for _, i := range l.Items {
+		pods = append(pods, p.runtimePod(ctx, i))
+	}
+	return pods, nil
+}
+
+// runtimePod summarizes the supplied pod. Failing to read logs or events is
+// not fatal; a crash looping pod may not have any. A logs error is reported
+// with the pod.
+func (p *Packages) runtimePod(ctx context.Context, i corev1.Pod) RuntimePod {
+	rp := RuntimePod{
+		Name:      i.GetName(),
+		Namespace: i.GetNamespace(),
+		Phase:     string(i.Status.Phase),
+		Logs:      []string{},
+		Events:    []object.Event{},
+	}
+	for _, cs := range i.Status.ContainerStatuses {
+		if cs.Name == RuntimeContainer || rp.Image == "" {
+			rp.Image, rp.ImageID = cs.Image, cs.ImageID
+		}
+	}
+
+	nn := types.NamespacedName{Namespace: i.GetNamespace(), Name: i.GetName()}
+	if logs, err := p.pod.GetLogs(ctx, nn, RuntimeContainer); err != nil {
+		rp.LogsError = err.Error()
+	} else if s := strings.TrimSuffix(string(logs), "\n"); s != "" {
+		rp.Logs = strings.Split(s, "\n")
+	}
+
+	events, err := p.pod.GetEvents(ctx, nn)
+	if err != nil {
+		p.log.Debug("failed to read runtime pod events", "pod", nn, "error", err)
+		return rp
+	}
+	rp.Events = events
+	return rp
+}
@@ -0,0 +1,204 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package xpkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+)
+
+func provider() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "Provider",
+		"metadata":   map[string]any{"name": "provider-aws"},
+		"spec":       map[string]any{"package": "xpkg.upbound.io/upbound/provider-aws:v1.0.0"},
+		"status": map[string]any{
+			"currentRevision": "provider-aws-abc",
+			"conditions": []any{
+				map[string]any{"type": "Installed", "status": "True", "reason": "ActivePackageRevision"},
+				map[string]any{"type": "Healthy", "status": "False", "reason": "UnhealthyPackageRevision"},
+			},
+		},
+	}}
+}
+
+func providerRevision(name, state string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1",
+		"kind":       "ProviderRevision",
+		"metadata": map[string]any{
+			"name":   name,
+			"labels": map[string]any{LabelPackage: "provider-aws"},
+		},
+		"spec": map[string]any{
+			"desiredState": state,
+			"revision":     int64(1),
+			"image":        "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+		},
+		"status": map[string]any{
+			"foundDependencies":     int64(2),
+			"installedDependencies": int64(1),
+		},
+	}}
+}
+
+func lock() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pkg.crossplane.io/v1beta1",
+		"kind":       "Lock",
+		"metadata":   map[string]any{"name": "lock"},
+		"packages": []any{
+			map[string]any{
+				"name":   "provider-aws-abc",
+				"source": "xpkg.upbound.io/upbound/provider-aws",
+				"dependencies": []any{
+					map[string]any{"package": "xpkg.upbound.io/upbound/provider-family-aws", "constraints": ">=v1.0.0", "type": "Provider"},
+					map[string]any{"package": "xpkg.upbound.io/upbound/function-missing", "constraints": ">=v0.1.0", "type": "Function"},
+				},
+			},
+			map[string]any{
+				"name":   "provider-family-aws-def",
+				"source": "xpkg.upbound.io/upbound/provider-family-aws",
+			},
+		},
+	}}
+}
+
+func runtimePod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "crossplane-system",
+			Name:      "provider-aws-abc-123",
+			Labels:    map[string]string{LabelRevision: "provider-aws-abc"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    RuntimeContainer,
+				Image:   "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+				ImageID: "xpkg.upbound.io/upbound/provider-aws@sha256:deadbeef",
+			}},
+		},
+	}
+}
+
+func TestStatus(t *testing.T) {
+	type args struct {
+		objs  []*unstructured.Unstructured
+		typed []runtime.Object
+	}
+	type want struct {
+		status *Status
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "An error should be returned if the package does not exist.",
+			args: args{
+				objs: []*unstructured.Unstructured{providerRevision("provider-aws-abc", "Active")},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"Unhealthy": {
+			reason: "The status should include revisions, unresolved dependencies and the runtime pods.",
+			args: args{
+				objs: []*unstructured.Unstructured{
+					provider(),
+					providerRevision("provider-aws-abc", "Active"),
+					providerRevision("provider-aws-old", "Inactive"),
+					lock(),
+				},
+				typed: []runtime.Object{runtimePod()},
+			},
+			want: want{
+				status: &Status{
+					Summary: object.Summary{
+						APIVersion: "pkg.crossplane.io/v1",
+						Kind:       "Provider",
+						Name:       "provider-aws",
+						Conditions: []object.Condition{
+							{Type: "Installed", Status: "True", Reason: "ActivePackageRevision"},
+							{Type: "Healthy", Status: "False", Reason: "UnhealthyPackageRevision"},
+						},
+					},
+					Package:         "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+					CurrentRevision: "provider-aws-abc",
+					DesiredRevision: "provider-aws-abc",
+					ImageDigest:     "sha256:deadbeef",
+					Revisions: []Revision{
+						{Name: "provider-aws-abc", Revision: 1, DesiredState: "Active", Image: "xpkg.upbound.io/upbound/provider-aws:v1.0.0", FoundDependencies: 2, InstalledDependencies: 1},
+						{Name: "provider-aws-old", Revision: 1, DesiredState: "Inactive", Image: "xpkg.upbound.io/upbound/provider-aws:v1.0.0", FoundDependencies: 2, InstalledDependencies: 1},
+					},
+					Dependencies: []Dependency{
+						{Package: "xpkg.upbound.io/upbound/provider-family-aws", Constraints: ">=v1.0.0", Type: "Provider", Resolved: true},
+						{Package: "xpkg.upbound.io/upbound/function-missing", Constraints: ">=v0.1.0", Type: "Function"},
+					},
+					DependencyErrors: []string{
+						"1 of 2 dependencies are not installed",
+						"dependency xpkg.upbound.io/upbound/function-missing (>=v0.1.0) is not present in the lock",
+					},
+					Pods: []RuntimePod{{
+						Name:      "provider-aws-abc-123",
+						Namespace: "crossplane-system",
+						Phase:     "Running",
+						Image:     "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+						ImageID:   "xpkg.upbound.io/upbound/provider-aws@sha256:deadbeef",
+						Logs:      []string{"fake logs"},
+						Events:    []object.Event{},
+					}},
+					Events: []object.Event{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cs := fake.NewClientset(tc.args.typed...)
+			p := New(objectfake.NewClientWithClientset(cs, nil, tc.args.objs), pod.New(cs))
+
+			got, err := p.Status(context.Background(), KindProvider, "provider-aws")
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nStatus(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.status, got, cmpopts.SortSlices(func(a, b Revision) bool { return a.Name < b.Name })); diff != "" {
+				t.Errorf("\n%s\nStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/upbound/controlplane-mcp-server/internal/budget"
+)
+
+const (
+	paramMaxTokens = "maxTokens"
+	paramMaxBytes  = "maxBytes"
+)
+
+// noticeStructuredOmitted is appended to a result whose structured content was
+// dropped because it exceeded the caller's budget.
+const noticeStructuredOmitted = "[truncated: structured content of about %d tokens omitted to fit the response budget; " +
+	"raise maxTokens/maxBytes or narrow the request to see it]"
+
+const (
+	keyConditions = "conditions"
+	keyEvents     = "events"
+)
+
+// withBudget adds the optional response budget parameters to a tool.
+func withBudget(t mcp.Tool) mcp.Tool {
+	mcp.WithNumber(paramMaxTokens,
+		mcp.Description("Optional maximum number of tokens to return. Larger results are truncated and marked as such."),
+	)(&t)
+	mcp.WithNumber(paramMaxBytes,
+		mcp.Description("Optional maximum number of bytes to return. Larger results are truncated and marked as such."),
+	)(&t)
+	return t
+}
+
+// budgetFrom returns the response budget supplied with the request.
+func budgetFrom(req mcp.CallToolRequest) budget.Budget {
+	return budget.New(req.GetInt(paramMaxTokens, 0), req.GetInt(paramMaxBytes, 0))
+}
+
+// enforceBudget wraps a handler so that any text it returns that still
+// exceeds the caller's budget is cut down to size. Structured content that
+// exceeds it first has its conditions and events trimmed, keeping the highest
+// severity conditions and the most recent events, and is dropped in favour of
+// a truncation notice if it still doesn't fit. Handlers that know how to shape
+// their own output should do so first; this is the safety net for those that
+// don't.
+func enforceBudget(h server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := h(ctx, req)
+		if err != nil || res == nil || res.IsError {
+			return res, err
+		}
+
+		b := budgetFrom(req)
+		if b.Unlimited() {
+			return res, nil
+		}
+
+		var ts []*budget.Truncation
+		if res.StructuredContent != nil {
+			ts = shapeStructured(b, res)
+		}
+
+		for i, c := range res.Content {
+			tc, ok := c.(mcp.TextContent)
+			if !ok || b.Fits(tc.Text) {
+				continue
+			}
+			text, t := b.Lines(tc.Text)
+			tc.Text = text + "\n" + t.String()
+			res.Content[i] = tc
+		}
+
+		for _, t := range ts {
+			res.Content = append(res.Content, mcp.NewTextContent(t.String()))
+		}
+
+		if res.StructuredContent == nil {
+			return res, nil
+		}
+		j, err := json.Marshal(res.StructuredContent)
+		if err == nil && b.Fits(string(j)) {
+			return res, nil
+		}
+		res.StructuredContent = nil
+		res.Content = append(res.Content, mcp.NewTextContent(fmt.Sprintf(noticeStructuredOmitted, budget.EstimateTokens(string(j)))))
+		return res, nil
+	}
+}
+
+// A trimmable list of conditions or events within structured content.
+type trimmable struct {
+	parent map[string]any
+	key    string
+	items  []string
+}
+
+// size of the list when rendered as JSON.
+func (l trimmable) size() int {
+	size := 0
+	for _, i := range l.items {
+		size += len(i) + 1
+	}
+	return size
+}
+
+// shapeStructured trims the conditions and events in the supplied result's
+// structured content if it exceeds the budget. Each list is given a share of
+// the budget in proportion to its size. Conditions keep the highest severity
+// entries and events, which are ordered from oldest to most recent, keep the
+// most recent. A text rendering of the structured content is replaced with a
+// rendering of the trimmed content.
+func shapeStructured(b budget.Budget, res *mcp.CallToolResult) []*budget.Truncation {
+	j, err := json.Marshal(res.StructuredContent)
+	if err != nil || b.Fits(string(j)) {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(j, &v); err != nil {
+		return nil
+	}
+
+	lists := trimmables(v, nil)
+	size := 0
+	for _, l := range lists {
+		size += l.size()
+	}
+	fixed := len(j) - size
+	if size == 0 || fixed >= b.MaxBytes() {
+		return nil
+	}
+
+	var ts []*budget.Truncation
+	for _, l := range lists {
+		lb := budget.New(0, max(l.size()*(b.MaxBytes()-fixed)/size, 1))
+		var (
+			kept []string
+			t    *budget.Truncation
+		)
+		switch l.key {
+		case keyConditions:
+			kept, t = lb.Prioritized(l.key, l.items, func(i int) int { return severity(l.items[i]) })
+		default:
+			kept, t = lb.Tail(l.key, l.items)
+		}
+		if t == nil {
+			continue
+		}
+		out := make([]any, 0, len(kept))
+		for _, k := range kept {
+			var item any
+			_ = json.Unmarshal([]byte(k), &item)
+			out = append(out, item)
+		}
+		l.parent[l.key] = out
+		ts = append(ts, t)
+	}
+
+	// Replace a text rendering of the original structured content, such as
+	// the one produced by structured, so that it is trimmed the same way.
+	if indented, err := json.MarshalIndent(res.StructuredContent, "", "  "); err == nil {
+		for i, c := range res.Content {
+			if tc, ok := c.(mcp.TextContent); ok && tc.Text == string(indented) {
+				trimmed, _ := json.MarshalIndent(v, "", "  ")
+				tc.Text = string(trimmed)
+				res.Content[i] = tc
+			}
+		}
+	}
+	res.StructuredContent = v
+	return ts
+}
+
+// trimmables returns the lists of conditions and events nested anywhere in
+// the supplied value, rendering each item as JSON.
+func trimmables(v any, out []trimmable) []trimmable {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(t)) {
+			c := t[k]
+			if items, ok := c.([]any); ok && (k == keyConditions || k == keyEvents) {
+				l := trimmable{parent: t, key: k, items: make([]string, 0, len(items))}
+				for _, i := range items {
+					b, _ := json.Marshal(i)
+					l.items = append(l.items, string(b))
+				}
+				out = append(out, l)
+				continue
+			}
+			out = trimmables(c, out)
+		}
+	case []any:
+		for _, c := range t {
+			out = trimmables(c, out)
+		}
+	}
+	return out
+}
+
+// severity of the supplied condition rendered as JSON. Conditions that are
+// False are the most severe, followed by those that are Unknown.
+func severity(condition string) int {
+	var c struct {
+		Status string `json:"status"`
+	}
+	_ = json.Unmarshal([]byte(condition), &c)
+	switch c.Status {
+	case "False":
+		return 2
+	case "Unknown":
+		return 1
+	}
+	return 0
+}
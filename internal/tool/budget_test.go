@@ -0,0 +1,138 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+func TestEnforceBudgetStructured(t *testing.T) {
+	lines := make([]string, 0, 200)
+	for i := range 200 {
+		lines = append(lines, fmt.Sprintf("line-%03d", i))
+	}
+	h := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return structured(PodLogs{Version: OutputVersion, Namespace: "default", Pod: "p", Lines: lines})
+	}
+
+	type want struct {
+		structured bool
+		notice     bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   map[string]any
+		want   want
+	}{
+		"Unlimited": {
+			reason: "Structured content should be returned untouched without a budget.",
+			want:   want{structured: true},
+		},
+		"WithinBudget": {
+			reason: "Structured content that fits the budget should be returned untouched.",
+			args:   map[string]any{paramMaxBytes: 1 << 20},
+			want:   want{structured: true},
+		},
+		"OverBudget": {
+			reason: "Structured content that exceeds the budget should be dropped and a notice returned instead.",
+			args:   map[string]any{paramMaxBytes: 1024},
+			want:   want{notice: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			res, err := enforceBudget(h)(context.Background(), request(tc.args))
+			if err != nil {
+				t.Fatalf("enforceBudget(...): unexpected error: %v", err)
+			}
+
+			text := ""
+			for _, c := range res.Content {
+				if t, ok := c.(mcp.TextContent); ok {
+					text += t.Text
+				}
+			}
+			last, _ := res.Content[len(res.Content)-1].(mcp.TextContent)
+			got := want{
+				structured: res.StructuredContent != nil,
+				notice:     strings.HasPrefix(last.Text, "[truncated: structured content of about"),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nenforceBudget(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if b := budgetFrom(request(tc.args)); !b.Unlimited() && len(text) > b.MaxBytes()+len(last.Text) {
+				t.Errorf("\n%s\nenforceBudget(...): text content is %d bytes, want at most %d", tc.reason, len(text), b.MaxBytes()+len(last.Text))
+			}
+		})
+	}
+}
+
+func TestEnforceBudgetConditions(t *testing.T) {
+	type status struct {
+		Conditions []object.Condition `json:"conditions"`
+		Events     []object.Event     `json:"events"`
+	}
+	in := status{}
+	for i := range 20 {
+		in.Conditions = append(in.Conditions, object.Condition{Type: fmt.Sprintf("Healthy%02d", i), Status: "True", Message: strings.Repeat("fine ", 10)})
+		in.Events = append(in.Events, object.Event{Reason: fmt.Sprintf("Event%02d", i), Message: strings.Repeat("noise ", 10)})
+	}
+	in.Conditions[7] = object.Condition{Type: "Synced", Status: "False", Message: "cannot connect"}
+	in.Conditions[13] = object.Condition{Type: "Ready", Status: "Unknown"}
+	h := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return structured(in)
+	}
+
+	res, err := enforceBudget(h)(context.Background(), request(map[string]any{paramMaxBytes: 2048}))
+	if err != nil {
+		t.Fatalf("enforceBudget(...): unexpected error: %v", err)
+	}
+	out, ok := res.StructuredContent.(map[string]any)
+	if !ok {
+		t.Fatalf("enforceBudget(...): structured content should be trimmed rather than omitted, got %T", res.StructuredContent)
+	}
+
+	conditions := []string{}
+	for _, c := range out["conditions"].([]any) {
+		conditions = append(conditions, c.(map[string]any)["type"].(string))
+	}
+	if !slices.Contains(conditions, "Synced") || !slices.Contains(conditions, "Ready") {
+		t.Errorf("enforceBudget(...): the False and Unknown conditions should be kept, got %v", conditions)
+	}
+	if len(conditions) == len(in.Conditions) {
+		t.Errorf("enforceBudget(...): conditions should be trimmed, kept all %d", len(conditions))
+	}
+
+	events := out["events"].([]any)
+	if len(events) == 0 || len(events) == len(in.Events) {
+		t.Fatalf("enforceBudget(...): events should be trimmed, kept %d of %d", len(events), len(in.Events))
+	}
+	if diff := cmp.Diff("Event19", events[len(events)-1].(map[string]any)["reason"]); diff != "" {
+		t.Errorf("\nThe most recent events should be kept.\nenforceBudget(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,62 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const getConnectionDetails = "get_connection_details"
+
+// GetConnectionDetails creates a new mcp.Tool for inspecting the connection
+// details of a claim, composite resource or managed resource.
+func GetConnectionDetails() mcp.Tool {
+	return mcp.NewTool(getConnectionDetails,
+		mcp.WithDescription(`
+Inspect where the given claim, composite resource (XR) or managed resource
+writes its connection details. Follows writeConnectionSecretToRef and
+publishConnectionDetailsTo, and for a claim also its XR, then confirms each
+connection Secret exists and returns its key names, the size of each value,
+when it was last updated and the object that owns it. Secret values are never
+returned. If the server isn't allowed to read Secrets each Secret reports that
+its check was skipped. Useful when an application is missing credentials.
+`),
+		withObjectRef("claim, composite resource or managed resource"),
+		mcp.WithOutputSchema[ConnectionDetails](),
+	)
+}
+
+// GetConnectionDetailsHandler handles tool requests to inspect connection
+// details.
+func (s *Server) GetConnectionDetailsHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getConnectionDetails)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	d, err := s.conn.Details(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ConnectionDetails{Version: OutputVersion, Details: *d})
+}
@@ -0,0 +1,55 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const getCrossplaneHealth = "get_crossplane_health"
+
+// GetCrossplaneHealth creates a new mcp.Tool for checking the health of
+// Crossplane itself.
+func GetCrossplaneHealth() mcp.Tool {
+	return mcp.NewTool(getCrossplaneHealth,
+		mcp.WithDescription(`
+Check the health of Crossplane itself. Returns the readiness, version and
+enabled feature flags of the Crossplane and RBAC manager Deployments, the
+recent error lines logged by their pods, the holders of their leader election
+Leases and whether those Leases have expired, and whether Crossplane's
+validating and mutating webhooks have a CA bundle and a Service with ready
+endpoints. Use it when many resources fail at once, to rule out Crossplane.
+`),
+		mcp.WithOutputSchema[CrossplaneHealth](),
+	)
+}
+
+// GetCrossplaneHealthHandler handles tool requests to check the health of
+// Crossplane.
+func (s *Server) GetCrossplaneHealthHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getCrossplaneHealth)
+	log.Debug("received request")
+
+	h, err := s.core.Health(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(CrossplaneHealth{Version: OutputVersion, Health: *h})
+}
@@ -0,0 +1,59 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const describeClaim = "describe_claim"
+
+// DescribeClaim creates a new mcp.Tool for describing a Crossplane claim.
+func DescribeClaim() mcp.Tool {
+	return mcp.NewTool(describeClaim,
+		mcp.WithDescription(`
+Describe the given Crossplane claim. Returns the composite resource (XR) it is
+bound to, or why it isn't bound, how its Composition is selected (enforced,
+label selector, explicit reference or the XRD's default) along with the
+Compositions a selector matches, where it and its XR write their connection
+details, its events and the most recent events in its namespace. Secret values
+are never returned.
+`),
+		withObjectRef("claim"),
+		mcp.WithOutputSchema[ClaimDescription](),
+	)
+}
+
+// DescribeClaimHandler handles tool requests to describe a claim.
+func (s *Server) DescribeClaimHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", describeClaim)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	d, err := s.claims.Describe(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ClaimDescription{Version: OutputVersion, Description: *d})
+}
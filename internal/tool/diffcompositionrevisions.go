@@ -0,0 +1,68 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const diffCompositionRevisions = "diff_composition_revisions"
+
+// DiffCompositionRevisions creates a new mcp.Tool for diffing revisions of a
+// Composition.
+func DiffCompositionRevisions() mcp.Tool {
+	return mcp.NewTool(diffCompositionRevisions,
+		mcp.WithDescription(`
+Produce a semantic diff between two CompositionRevisions of a Composition, or
+between a CompositionRevision and the live Composition. Differences are grouped
+by pipeline step, or resource template for Resources mode Compositions, and
+by other top level spec fields, with the path, old and new value of every
+changed field. Also lists the composite resources (XRs) using the Composition,
+the revision each one is on and whether its compositionUpdatePolicy pins it
+there, to help reason about the blast radius of a change.
+`),
+		mcp.WithString("from",
+			mcp.Required(),
+			mcp.Description("The name of the CompositionRevision to diff from"),
+		),
+		mcp.WithString("to",
+			mcp.Description("The name of the CompositionRevision to diff to. Defaults to the live Composition"),
+		),
+		mcp.WithOutputSchema[CompositionRevisionDiff](),
+	)
+}
+
+// DiffCompositionRevisionsHandler handles tool requests to diff revisions
+// of a Composition.
+func (s *Server) DiffCompositionRevisionsHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", diffCompositionRevisions)
+	log.Debug("received request")
+
+	from, err := req.RequireString("from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	d, err := s.comp.Diff(ctx, from, req.GetString("to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(CompositionRevisionDiff{Version: OutputVersion, Diff: *d})
+}
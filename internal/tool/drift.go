@@ -0,0 +1,61 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const getDrift = "get_drift"
+
+// GetDrift creates a new mcp.Tool for comparing the desired and observed
+// state of a managed resource.
+func GetDrift() mcp.Tool {
+	return mcp.NewTool(getDrift,
+		mcp.WithDescription(`
+Compare the desired state of the given managed resource in spec.forProvider
+with the observed state in status.atProvider. Returns the fields whose
+observed value differs or isn't reported, the initProvider fields that are
+only applied on creation, the spec.forProvider fields the provider
+late-initialized, and whether the resource's events show it flapping between
+updates or between errors and successful reconciles.
+`),
+		withObjectRef("managed resource"),
+		mcp.WithOutputSchema[ManagedResourceDrift](),
+	)
+}
+
+// GetDriftHandler handles tool requests to compare the desired and observed
+// state of a managed resource.
+func (s *Server) GetDriftHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getDrift)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	d, err := s.mr.Drift(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ManagedResourceDrift{Version: OutputVersion, Drift: *d})
+}
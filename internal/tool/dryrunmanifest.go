@@ -0,0 +1,52 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const dryRunManifest = "dry_run_manifest"
+
+// DryRunManifest creates a new mcp.Tool for validating manifests with a
+// server-side dry-run.
+func DryRunManifest() mcp.Tool {
+	return mcp.NewTool(dryRunManifest,
+		mcp.WithDescription(`
+Validate manifests like validate_manifest, then send each to the API server
+as a server-side dry-run create, which also runs admission webhooks. The
+dry-run is a create request that the API server never persists, but it
+requires create permission on each kind and admission webhooks may have side
+effects.
+`),
+		mcp.WithString("manifest",
+			mcp.Required(),
+			mcp.Description("The YAML or JSON manifests to validate. Multiple YAML documents may be separated by ---"),
+		),
+		mcp.WithOutputSchema[ManifestValidation](),
+	)
+}
+
+// DryRunManifestHandler handles tool requests to dry-run manifests.
+func (s *Server) DryRunManifestHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", dryRunManifest)
+	log.Debug("received request")
+
+	return s.validateManifests(ctx, req, true)
+}
@@ -0,0 +1,90 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/explain"
+)
+
+const (
+	explainAPI = "explain_api"
+
+	// defaultExplainDepth is the default number of levels of nested fields
+	// to explain.
+	defaultExplainDepth = 3
+)
+
+// ExplainAPI creates a new mcp.Tool for explaining the schema of an API.
+func ExplainAPI() mcp.Tool {
+	return mcp.NewTool(explainAPI,
+		mcp.WithDescription(`
+Explain an API served by a CustomResourceDefinition, like 'kubectl explain'.
+Renders the schema tree with descriptions, types, required fields, defaults
+and enums. For composite resource and claim APIs defined by a Crossplane
+CompositeResourceDefinition (XRD) it also returns the composite and claim
+names, the default and enforced Compositions, the Compositions offered for
+the composite type with the labels a compositionSelector can match, and the
+connection secret keys.
+`),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The API to explain: a kind, plural, singular or short name, or the name of its CRD or XRD, e.g. XPostgres or xpostgres.example.org"),
+		),
+		mcp.WithString("group",
+			mcp.Description("The API group, to disambiguate APIs with the same name"),
+		),
+		mcp.WithString("version",
+			mcp.Description("The API version to explain. Defaults to the storage version"),
+		),
+		mcp.WithString("field",
+			mcp.Description("A dot separated path of the field to explain, e.g. spec.parameters. Defaults to the whole API"),
+		),
+		mcp.WithNumber("maxDepth",
+			mcp.Description("The number of levels of nested fields to include. Use 0 for no limit. Defaults to 3"),
+			mcp.Min(0),
+		),
+		mcp.WithOutputSchema[APIExplanation](),
+	)
+}
+
+// ExplainAPIHandler handles tool requests to explain an API.
+func (s *Server) ExplainAPIHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", explainAPI)
+	log.Debug("received request")
+
+	name, err := req.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ex, err := s.exp.Explain(ctx, explain.Request{
+		Name:     name,
+		Group:    req.GetString("group", ""),
+		Version:  req.GetString("version", ""),
+		Field:    req.GetString("field", ""),
+		MaxDepth: req.GetInt("maxDepth", defaultExplainDepth),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(APIExplanation{Version: OutputVersion, Explanation: *ex})
+}
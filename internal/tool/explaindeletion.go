@@ -0,0 +1,61 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const explainDeletion = "explain_deletion"
+
+// ExplainDeletion creates a new mcp.Tool for explaining why the deletion of
+// a resource is blocked.
+func ExplainDeletion() mcp.Tool {
+	return mcp.NewTool(explainDeletion,
+		mcp.WithDescription(`
+Explain why the given resource, typically one with a deletionTimestamp, has
+not been deleted. Lists its finalizers and what removes them, the Crossplane
+Usages that block its deletion, the composed resources (or for a claim the
+composite resource) that still exist, the errors reported by its conditions
+and events, its deletionPolicy and managementPolicies, and whether its
+reconciliation is paused.
+`),
+		withObjectRef("resource"),
+		mcp.WithOutputSchema[DeletionExplanation](),
+	)
+}
+
+// ExplainDeletionHandler handles tool requests to explain why the deletion
+// of a resource is blocked.
+func (s *Server) ExplainDeletionHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", explainDeletion)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ex, err := s.del.Explain(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(DeletionExplanation{Version: OutputVersion, Explanation: *ex})
+}
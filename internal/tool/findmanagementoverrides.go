@@ -0,0 +1,85 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/fleet"
+)
+
+const findManagementOverrides = "find_management_overrides"
+
+// FindManagementOverrides creates a new mcp.Tool for finding the resources
+// whose reconciliation is intentionally limited.
+func FindManagementOverrides() mcp.Tool {
+	return mcp.NewTool(findManagementOverrides,
+		mcp.WithDescription(`
+Sweep every managed resource, composite resource (XR) and claim in the
+controlplane for those whose reconciliation is intentionally limited: resources
+paused by the crossplane.io/paused annotation, managed resources whose
+managementPolicies only allow Observe, and managed resources with other
+non-default managementPolicies. Returns each resource with what Crossplane
+won't do for it. Resources are paginated and every page carries the totals of
+the sweep. Check this before diagnosing a resource that isn't being updated;
+it may not be reconciled on purpose.
+`),
+		mcp.WithString("category",
+			mcp.Description("Only sweep resources in this category. Defaults to all categories"),
+			mcp.Enum(fleet.Categories()...),
+		),
+		withPagination(),
+		mcp.WithOutputSchema[ManagementOverrides](),
+	)
+}
+
+// FindManagementOverridesHandler handles tool requests to find the resources
+// whose reconciliation is intentionally limited.
+func (s *Server) FindManagementOverridesHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", findManagementOverrides)
+	log.Debug("received request")
+
+	categories := fleet.Categories()
+	if c := req.GetString("category", ""); c != "" {
+		categories = []string{c}
+	}
+
+	o, p, err := paginateWith(s, req, "resources", func() (fleet.Overrides, []fleet.Override, error) {
+		o, err := s.fleet.Overrides(ctx, categories...)
+		if err != nil {
+			return fleet.Overrides{}, nil, err
+		}
+		resources := o.Resources
+		o.Resources = nil
+		return *o, ranked(resources), nil
+	}, renderJSON[fleet.Override])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	o.Resources = p.firstToLast().Items
+
+	text := fmt.Sprintf("%d of %d resources of %d kinds are paused, %d only observe and %d have other management policies\n%s",
+		o.Paused, o.Scanned, o.Kinds, o.ObserveOnly, o.Limited, p.text("resources"))
+	if len(o.Errors) > 0 {
+		text = fmt.Sprintf("%s\nerrors:\n%s", text, strings.Join(o.Errors, "\n"))
+	}
+	return mcp.NewToolResultStructured(ManagementOverrides{Version: OutputVersion, Overrides: o, Page: p.info()}, text), nil
+}
@@ -0,0 +1,85 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/fleet"
+)
+
+const findUnhealthy = "find_unhealthy"
+
+// FindUnhealthy creates a new mcp.Tool for finding the unhealthy resources
+// in the controlplane.
+func FindUnhealthy() mcp.Tool {
+	return mcp.NewTool(findUnhealthy,
+		mcp.WithDescription(`
+Sweep every managed resource, composite resource (XR) and claim in the
+controlplane, discovered through the managed, composite and claim categories
+of their CustomResourceDefinitions, and return those that aren't Ready or
+aren't Synced. Results are grouped by the provider or
+CompositeResourceDefinition that owns the kind, the kind and the condition
+reason, with a count and a few example resources for each group, largest
+group first. Groups are paginated; the first page holds the largest groups
+and every page carries the totals of the sweep. A good first step when you
+don't yet know which resource is failing.
+`),
+		mcp.WithString("category",
+			mcp.Description("Only sweep resources in this category. Defaults to all categories"),
+			mcp.Enum(fleet.Categories()...),
+		),
+		withPagination(),
+		mcp.WithOutputSchema[UnhealthyResources](),
+	)
+}
+
+// FindUnhealthyHandler handles tool requests to find the unhealthy resources
+// in the controlplane.
+func (s *Server) FindUnhealthyHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", findUnhealthy)
+	log.Debug("received request")
+
+	categories := fleet.Categories()
+	if c := req.GetString("category", ""); c != "" {
+		categories = []string{c}
+	}
+
+	sw, p, err := paginateWith(s, req, "groups", func() (fleet.Sweep, []fleet.Group, error) {
+		sw, err := s.fleet.Unhealthy(ctx, categories...)
+		if err != nil {
+			return fleet.Sweep{}, nil, err
+		}
+		groups := sw.Groups
+		sw.Groups = nil
+		return *sw, ranked(groups), nil
+	}, renderJSON[fleet.Group])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sw.Groups = p.firstToLast().Items
+
+	text := fmt.Sprintf("%d of %d resources of %d kinds are unhealthy\n%s", sw.Unhealthy, sw.Scanned, sw.Kinds, p.text("groups"))
+	if len(sw.Errors) > 0 {
+		text = fmt.Sprintf("%s\nerrors:\n%s", text, strings.Join(sw.Errors, "\n"))
+	}
+	return mcp.NewToolResultStructured(UnhealthyResources{Version: OutputVersion, Sweep: sw, Page: p.info()}, text), nil
+}
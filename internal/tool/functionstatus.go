@@ -0,0 +1,67 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const getFunctionStatus = "get_function_status"
+
+// GetFunctionStatus creates a new mcp.Tool for diagnosing the runtime of a
+// Crossplane Function.
+func GetFunctionStatus() mcp.Tool {
+	return mcp.NewTool(getFunctionStatus,
+		mcp.WithDescription(`
+Diagnose the runtime of the given Crossplane Function package. Returns the
+package status with the recent events of its runtime pods and the logs of
+their package-runtime container, the Deployment running its active revision,
+the Service and ready endpoints behind its gRPC endpoint, whether its TLS
+server Secret exists with the expected keys, whether the Deployment reflects
+its DeploymentRuntimeConfig, and recent warning events of composite resources
+that failed to run the Function. Secret values are never returned. If the
+server isn't allowed to read Secrets the TLS Secret reports that its check was
+skipped.
+`),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the Function"),
+		),
+		mcp.WithOutputSchema[FunctionStatus](),
+	)
+}
+
+// GetFunctionStatusHandler handles tool requests to diagnose the runtime of
+// a Function.
+func (s *Server) GetFunctionStatusHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getFunctionStatus)
+	log.Debug("received request")
+
+	name, err := req.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fs, err := s.pkg.FunctionStatus(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(FunctionStatus{Version: OutputVersion, FunctionStatus: *fs})
+}
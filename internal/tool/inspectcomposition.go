@@ -0,0 +1,59 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const inspectComposition = "inspect_composition"
+
+// InspectComposition creates a new mcp.Tool for inspecting the composition
+// pipeline of a composite resource.
+func InspectComposition() mcp.Tool {
+	return mcp.NewTool(inspectComposition,
+		mcp.WithDescription(`
+Inspect how the given composite resource (XR) is composed. Resolves the XR's
+selected Composition and CompositionRevision, lists each pipeline step with its
+function reference, input kind, credentials and the referenced Function's
+health, and correlates the XR's events and conditions to the failing step.
+`),
+		withObjectRef("composite resource"),
+		mcp.WithOutputSchema[CompositionInspection](),
+	)
+}
+
+// InspectCompositionHandler handles tool requests to inspect a composite
+// resource's composition.
+func (s *Server) InspectCompositionHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", inspectComposition)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	in, err := s.comp.Inspect(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(CompositionInspection{Version: OutputVersion, Inspection: *in})
+}
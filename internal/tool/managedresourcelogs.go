@@ -0,0 +1,75 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	getManagedResourceLogs = "get_managed_resource_logs"
+
+	// defaultProviderLogLines is the default number of recent log lines
+	// searched in each provider pod.
+	defaultProviderLogLines = 1000
+	// maxProviderLogLines is the maximum number of recent log lines
+	// searched in each provider pod.
+	maxProviderLogLines = 10000
+)
+
+// GetManagedResourceLogs creates a new mcp.Tool for reading the provider
+// logs that mention a managed resource.
+func GetManagedResourceLogs() mcp.Tool {
+	return mcp.NewTool(getManagedResourceLogs,
+		mcp.WithDescription(`
+Retrieve the provider logs for the given managed resource. Finds the Provider
+that installed the managed resource's CRD, its active ProviderRevision and the
+pods running it, then returns the recent log lines of those pods that mention
+the managed resource's name, UID or external name. Useful when a managed
+resource is not Synced or not Ready.
+`),
+		withObjectRef("managed resource"),
+		mcp.WithNumber("tailLines",
+			mcp.Description("The number of recent log lines to search in each provider pod. Defaults to 1000"),
+			mcp.Min(1),
+			mcp.Max(maxProviderLogLines),
+		),
+		mcp.WithOutputSchema[ManagedResourceLogs](),
+	)
+}
+
+// GetManagedResourceLogsHandler handles tool requests to read the provider
+// logs that mention a managed resource.
+func (s *Server) GetManagedResourceLogsHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getManagedResourceLogs)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tail := min(max(req.GetInt("tailLines", defaultProviderLogLines), 1), maxProviderLogLines)
+
+	l, err := s.mr.Logs(ctx, gvk, nn, int64(tail))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ManagedResourceLogs{Version: OutputVersion, Logs: *l})
+}
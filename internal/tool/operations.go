@@ -0,0 +1,183 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/operation"
+)
+
+const (
+	listOperations            = "list_operations"
+	getOperation              = "get_operation"
+	findOperationsForResource = "find_operations_for_resource"
+)
+
+// ListOperations creates a new mcp.Tool for listing Crossplane Operations,
+// CronOperations and WatchOperations.
+func ListOperations() mcp.Tool {
+	return mcp.NewTool(listOperations,
+		mcp.WithDescription(`
+List the Crossplane Operations, CronOperations and WatchOperations in the
+controlplane, most recently created first. Operations report whether they are
+Running, Succeeded or Failed and the CronOperation or WatchOperation that
+created them. CronOperations and WatchOperations report their schedule or
+watched kind and when they last scheduled and last succeeded. Results are
+paginated; the first page holds the most recent operations.
+`),
+		mcp.WithString("kind",
+			mcp.Description("Only list operations of this kind. Defaults to all kinds"),
+			mcp.Enum(operation.Kinds()...),
+		),
+		withPagination(),
+		mcp.WithOutputSchema[OperationList](),
+	)
+}
+
+// ListOperationsHandler handles tool requests to list operations.
+func (s *Server) ListOperationsHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", listOperations)
+	log.Debug("received request")
+
+	kinds := operation.Kinds()
+	if k := req.GetString("kind", ""); k != "" {
+		kinds = []string{k}
+	}
+
+	p, err := paginate(s, req, "operations", func() ([]operation.Summary, error) {
+		l, err := s.ops.List(ctx, kinds...)
+		if err != nil {
+			return nil, err
+		}
+		return ranked(l.Operations), nil
+	}, renderJSON[operation.Summary])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	p.firstToLast()
+
+	out := OperationList{
+		Version: OutputVersion,
+		Listing: operation.Listing{Operations: p.Items},
+		Page:    p.info(),
+	}
+	return mcp.NewToolResultStructured(out, p.text("operations")), nil
+}
+
+// GetOperation creates a new mcp.Tool for describing a Crossplane
+// Operation, CronOperation or WatchOperation.
+func GetOperation() mcp.Tool {
+	return mcp.NewTool(getOperation,
+		mcp.WithDescription(`
+Describe a Crossplane Operation, CronOperation or WatchOperation. For an
+Operation returns its pipeline steps and their outputs, the resources it
+applied, its failure count and failure messages. For a CronOperation or
+WatchOperation returns its running Operations and the history of the most
+recent Operations it created, most recent first, with the number of older ones
+left out. Use list_operations to page through every Operation.
+`),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("The kind of the operation"),
+			mcp.Enum(operation.Kinds()...),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the operation"),
+		),
+		mcp.WithOutputSchema[OperationDetails](),
+	)
+}
+
+// GetOperationHandler handles tool requests to describe an operation.
+func (s *Server) GetOperationHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getOperation)
+	log.Debug("received request")
+
+	kind, err := req.RequireString("kind")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := req.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	d, err := s.ops.Get(ctx, kind, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(OperationDetails{Version: OutputVersion, Details: *d})
+}
+
+// FindOperationsForResource creates a new mcp.Tool for finding the
+// Operations that applied a resource.
+func FindOperationsForResource() mcp.Tool {
+	return mcp.NewTool(findOperationsForResource,
+		mcp.WithDescription(`
+Find the Crossplane Operations that applied the given resource, most recently
+completed first. The first Operation returned is the one that last touched the
+resource. Useful to explain unexpected changes to a resource. Results are
+paginated; the first page holds the most recently completed Operations.
+`),
+		withObjectRef("resource"),
+		withPagination(),
+		mcp.WithOutputSchema[OperationsForResource](),
+	)
+}
+
+// FindOperationsForResourceHandler handles tool requests to find the
+// Operations that applied a resource.
+func (s *Server) FindOperationsForResourceHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", findOperationsForResource)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ref := object.Reference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  nn.Namespace,
+		Name:       nn.Name,
+	}
+	p, err := paginate(s, req, "operations", func() ([]operation.Summary, error) {
+		t, err := s.ops.Touched(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		return ranked(t.Operations), nil
+	}, renderJSON[operation.Summary])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	p.firstToLast()
+
+	out := OperationsForResource{
+		Version: OutputVersion,
+		Touch:   operation.Touch{Resource: ref, Operations: p.Items},
+		Page:    p.info(),
+	}
+	return mcp.NewToolResultStructured(out, p.text("operations")), nil
+}
@@ -0,0 +1,236 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/explain"
+	"github.com/upbound/controlplane-mcp-server/internal/render"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/claim"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/connection"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/core"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/deletion"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/environment"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/fleet"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/graph"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/managed"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/operation"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/timing"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+	"github.com/upbound/controlplane-mcp-server/internal/validate"
+)
+
+// OutputVersion is the version of the structured output contract. It must be
+// bumped whenever a breaking change is made to any of the output types below.
+const OutputVersion = "v1alpha1"
+
+// structured builds a result from the supplied output, rendering it as
+// indented JSON for callers that only read text.
+func structured(out any) (*mcp.CallToolResult, error) {
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultStructured(out, string(b)), nil
+}
+
+// Page describes where a page sits within a paginated result.
+type Page struct {
+	Next      string `json:"next,omitempty" jsonschema_description:"Cursor for the next page of items. Empty if this is the last page."`
+	Remaining int    `json:"remaining"      jsonschema_description:"Number of items after this page."`
+}
+
+// PodLogs is the structured output of the get_pod_logs tool.
+type PodLogs struct {
+	Version   string   `json:"version"             jsonschema_description:"Version of the output contract."`
+	Namespace string   `json:"namespace"           jsonschema_description:"Namespace of the pod."`
+	Pod       string   `json:"pod"                 jsonschema_description:"Name of the pod."`
+	Container string   `json:"container,omitempty" jsonschema_description:"Name of the container whose logs were read, if one was requested."`
+	Lines     []string `json:"lines"               jsonschema_description:"Log lines ordered from oldest to most recent."`
+	Page      Page     `json:"page"`
+}
+
+// PodEvents is the structured output of the get_pod_events tool.
+type PodEvents struct {
+	Version   string  `json:"version"   jsonschema_description:"Version of the output contract."`
+	Namespace string  `json:"namespace" jsonschema_description:"Namespace of the pod."`
+	Pod       string  `json:"pod"       jsonschema_description:"Name of the pod."`
+	Events    []Event `json:"events"    jsonschema_description:"Events ordered from oldest to most recent."`
+	Page      Page    `json:"page"`
+}
+
+// PackageStatus is the structured output of the get_package_status tool.
+type PackageStatus struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	xpkg.Status
+}
+
+// CompositionInspection is the structured output of the inspect_composition
+// tool.
+type CompositionInspection struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	composition.Inspection
+}
+
+// CompositionRender is the structured output of the render_composition tool.
+type CompositionRender struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	render.Outputs
+}
+
+// ManifestValidation is the structured output of the validate_manifest tool.
+type ManifestValidation struct {
+	Version string            `json:"version" jsonschema_description:"Version of the output contract."`
+	Valid   bool              `json:"valid" jsonschema_description:"Whether every manifest is valid."`
+	Results []validate.Result `json:"results"`
+}
+
+// APIExplanation is the structured output of the explain_api tool.
+type APIExplanation struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	explain.Explanation
+}
+
+// ManagedResourceLogs is the structured output of the
+// get_managed_resource_logs tool.
+type ManagedResourceLogs struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	managed.Logs
+}
+
+// OperationList is the structured output of the list_operations tool.
+type OperationList struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	operation.Listing
+	Page Page `json:"page"`
+}
+
+// OperationDetails is the structured output of the get_operation tool.
+type OperationDetails struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	operation.Details
+}
+
+// OperationsForResource is the structured output of the
+// find_operations_for_resource tool.
+type OperationsForResource struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	operation.Touch
+	Page Page `json:"page"`
+}
+
+// ProviderConfigCheck is the structured output of the check_provider_config
+// tool.
+type ProviderConfigCheck struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	managed.ProviderConfigCheck
+}
+
+// ConnectionDetails is the structured output of the get_connection_details
+// tool.
+type ConnectionDetails struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	connection.Details
+}
+
+// DeletionExplanation is the structured output of the explain_deletion
+// tool.
+type DeletionExplanation struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	deletion.Explanation
+}
+
+// EnvironmentResolution is the structured output of the resolve_environment
+// tool.
+type EnvironmentResolution struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	environment.Resolution
+}
+
+// CompositionRevisionDiff is the structured output of the
+// diff_composition_revisions tool.
+type CompositionRevisionDiff struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	composition.Diff
+}
+
+// ManagedResourceDrift is the structured output of the get_drift tool.
+type ManagedResourceDrift struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	managed.Drift
+}
+
+// UnhealthyResources is the structured output of the find_unhealthy tool.
+type UnhealthyResources struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	fleet.Sweep
+	Page Page `json:"page"`
+}
+
+// FunctionStatus is the structured output of the get_function_status tool.
+type FunctionStatus struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	xpkg.FunctionStatus
+}
+
+// ManagementOverrides is the structured output of the
+// find_management_overrides tool.
+type ManagementOverrides struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	fleet.Overrides
+	Page Page `json:"page"`
+}
+
+// ClaimDescription is the structured output of the describe_claim tool.
+type ClaimDescription struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	claim.Description
+}
+
+// CrossplaneHealth is the structured output of the get_crossplane_health
+// tool.
+type CrossplaneHealth struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	core.Health
+}
+
+// ReconcileTiming is the structured output of the get_reconcile_timing tool.
+type ReconcileTiming struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	timing.Timing
+}
+
+// ResourceGraph is the structured output of the get_resource_graph tool.
+type ResourceGraph struct {
+	Version string `json:"version" jsonschema_description:"Version of the output contract."`
+	graph.Graph
+}
+
+// Event is a Kubernetes event minus the attributes that aren't useful for
+// analysis.
+type Event = object.Event
+
+// ResourceSummary summarizes the state of a Kubernetes resource.
+type ResourceSummary = object.Summary
+
+// Condition is a status condition of a Kubernetes resource.
+type Condition = object.Condition
@@ -0,0 +1,71 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOutputSchemas(t *testing.T) {
+	for _, e := range NewServer(nil, nil, nil).Entries() {
+		if len(e.Tool.RawOutputSchema) == 0 {
+			t.Errorf("%s: every tool must declare an output schema", e.Name())
+		}
+	}
+}
+
+func TestGetPodEventsStructured(t *testing.T) {
+	cs := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1"}},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "event-1"},
+			InvolvedObject: corev1.ObjectReference{Name: "pod-1"},
+			Reason:         "some reason",
+			Message:        "some message",
+		},
+	)
+
+	res, err := NewServer(cs, nil, nil).GetPodEventsHander(context.Background(), request(map[string]any{
+		"namespace": "default",
+		"pod":       "pod-1",
+	}))
+	if err != nil {
+		t.Fatalf("GetPodEventsHander(...): unexpected error: %v", err)
+	}
+
+	got, ok := res.StructuredContent.(PodEvents)
+	if !ok {
+		t.Fatalf("GetPodEventsHander(...): expected structured PodEvents, got %T", res.StructuredContent)
+	}
+
+	want := PodEvents{
+		Version:   OutputVersion,
+		Namespace: "default",
+		Pod:       "pod-1",
+		Events:    []Event{{Reason: "some reason", Message: "some message"}},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Event{}, "EventTime", "FirstTimestamp", "LastTimestamp")); diff != "" {
+		t.Errorf("GetPodEventsHander(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,74 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+)
+
+const getPackageStatus = "get_package_status"
+
+// GetPackageStatus creates a new mcp.Tool for retrieving the installation and
+// health status of a Crossplane package.
+func GetPackageStatus() mcp.Tool {
+	return mcp.NewTool(getPackageStatus,
+		mcp.WithDescription(`
+Read the installation and health status of the given Crossplane Provider,
+Function or Configuration. Includes its Installed and Healthy conditions, the
+current and desired revision, the image digest, dependency resolution errors
+from the package Lock, and the recent events of the pods running it with the
+logs of their package-runtime container.
+`),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("The kind of the Crossplane package"),
+			mcp.Enum(xpkg.Kinds()...),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the Crossplane package"),
+		),
+		mcp.WithOutputSchema[PackageStatus](),
+	)
+}
+
+// GetPackageStatusHandler handles tool requests to retrieve package status.
+func (s *Server) GetPackageStatusHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getPackageStatus)
+	log.Debug("received request")
+
+	kind, err := req.RequireString("kind")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, err := req.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	st, err := s.pkg.Status(ctx, kind, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(PackageStatus{Version: OutputVersion, Status: *st})
+}
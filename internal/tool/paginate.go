@@ -0,0 +1,209 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/controlplane-mcp-server/internal/cursor"
+)
+
+const (
+	paramCursor   = "cursor"
+	paramPageSize = "pageSize"
+
+	// defaultPageSize is the number of items returned per page when the
+	// caller doesn't specify one.
+	defaultPageSize = 10
+)
+
+// withPagination adds the optional pagination parameters to a tool.
+func withPagination() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithString(paramCursor,
+			mcp.Description("Opaque cursor returned by a previous call to continue paging through the remaining results."),
+		)(t)
+		mcp.WithNumber(paramPageSize,
+			mcp.Description(fmt.Sprintf("Optional number of items to return per page. Defaults to %d.", defaultPageSize)),
+		)(t)
+	}
+}
+
+// page is a single page of a paginated result.
+type page[T any] struct {
+	// Items in the page, ordered from oldest to most recent.
+	Items []T
+	// Lines are the text renderings of the items.
+	Lines []string
+	// Next is the cursor for the following page, if any.
+	Next string
+	// Remaining is the number of items after this page.
+	Remaining int
+}
+
+// snapshot is what a cursor holds between pages: the header returned with
+// every page and the items being paged through.
+type snapshot[H, T any] struct {
+	Header H
+	Items  []T
+}
+
+// origin identifies what a paginated request reads: the tool and every
+// argument other than those controlling paging and budgets. Cursors are only
+// honoured for requests with the same origin, so a cursor can't be replayed
+// against another tool, pod or namespace.
+func origin(req mcp.CallToolRequest) string {
+	args := maps.Clone(req.GetArguments())
+	for _, p := range []string{paramCursor, paramPageSize, paramMaxTokens, paramMaxBytes} {
+		delete(args, p)
+	}
+	// Maps marshal with sorted keys, so equal arguments yield equal origins.
+	b, _ := json.Marshal(args)
+	return req.Params.Name + " " + string(b)
+}
+
+// paginate returns the page of items requested by the caller. Pages are
+// served from the most recent items backwards, so the first page holds the
+// most recent items. The fetch function is only called when the request
+// doesn't carry a cursor; otherwise the snapshot taken for the first page is
+// used so that paging is deterministic. Cursors are bound to the origin of
+// the request that produced them. Items are rendered as text by the supplied
+// function to fit them within the caller's budget.
+func paginate[T any](s *Server, req mcp.CallToolRequest, unit string, fetch func() ([]T, error), render func(T) string) (*page[T], error) {
+	_, p, err := paginateWith(s, req, unit, func() (struct{}, []T, error) {
+		items, err := fetch()
+		return struct{}{}, items, err
+	}, render)
+	return p, err
+}
+
+// paginateWith is like paginate, but the fetch function also returns a
+// header, such as the totals of a sweep, that is snapshotted with the items
+// and returned with every page.
+func paginateWith[H, T any](s *Server, req mcp.CallToolRequest, unit string, fetch func() (H, []T, error), render func(T) string) (H, *page[T], error) {
+	var (
+		snap   snapshot[H, T]
+		id     string
+		offset int
+		err    error
+	)
+
+	if c := req.GetString(paramCursor, ""); c != "" {
+		if id, offset, err = cursor.Decode(c); err != nil {
+			return snap.Header, nil, err
+		}
+		held, err := s.cursors.Get(id, origin(req))
+		if err != nil {
+			return snap.Header, nil, err
+		}
+		var ok bool
+		if snap, ok = held.(snapshot[H, T]); !ok {
+			return snap.Header, nil, errors.Errorf("cursor doesn't hold %s, call the tool again without a cursor", unit)
+		}
+	} else if snap.Header, snap.Items, err = fetch(); err != nil {
+		return snap.Header, nil, err
+	}
+	items := snap.Items
+
+	size := req.GetInt(paramPageSize, defaultPageSize)
+	if size <= 0 {
+		size = defaultPageSize
+	}
+
+	end := max(len(items)-offset, 0)
+	start := max(end-size, 0)
+
+	lines := make([]string, 0, end-start)
+	for _, i := range items[start:end] {
+		lines = append(lines, render(i))
+	}
+
+	// Shrink the page rather than truncating it so that nothing is skipped
+	// when the caller moves on to the next page.
+	kept, _ := budgetFrom(req).Tail(unit, lines)
+	if len(kept) == 0 && end > start {
+		kept = lines[len(lines)-1:]
+	}
+	start = end - len(kept)
+
+	p := &page[T]{Items: append([]T{}, items[start:end]...), Lines: kept, Remaining: start}
+	if start > 0 {
+		if id == "" {
+			id = s.cursors.Put(origin(req), snap)
+		}
+		p.Next = cursor.Encode(id, len(items)-start)
+	}
+	return snap.Header, p, nil
+}
+
+// ranked returns a copy of the supplied items, which are ranked first to
+// last, such as most recent or largest first, in the order paginate serves
+// them from. Reverse each page back with firstToLast.
+func ranked[T any](items []T) []T {
+	out := slices.Clone(items)
+	slices.Reverse(out)
+	return out
+}
+
+// firstToLast reverses a page of ranked items so that they are ordered first
+// to last.
+func (p *page[T]) firstToLast() *page[T] {
+	slices.Reverse(p.Items)
+	slices.Reverse(p.Lines)
+	return p
+}
+
+// renderJSON renders the supplied item as a single line of JSON.
+func renderJSON[T any](item T) string {
+	// Items are plain structs, which always marshal.
+	b, _ := json.Marshal(item)
+	return string(b)
+}
+
+// splitLines splits the supplied output into lines, ignoring a trailing
+// newline.
+func splitLines(b []byte) []string {
+	s := strings.TrimSuffix(string(b), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// text renders the page, appending the cursor for the next page if there is
+// one.
+func (p *page[T]) text(unit string) string {
+	text := strings.Join(p.Lines, "\n")
+	if p.Next == "" {
+		return text
+	}
+	return fmt.Sprintf("%s\n[%d more %s available: call again with %s=%q]",
+		text, p.Remaining, unit, paramCursor, p.Next)
+}
+
+// info returns the structured description of the page.
+func (p *page[T]) info() Page {
+	return Page{Next: p.Next, Remaining: p.Remaining}
+}
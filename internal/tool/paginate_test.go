@@ -0,0 +1,197 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func request(args map[string]any) mcp.CallToolRequest {
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	return req
+}
+
+func identity(s string) string { return s }
+
+func TestPaginate(t *testing.T) {
+	items := make([]string, 0, 25)
+	for i := range 25 {
+		items = append(items, fmt.Sprintf("line-%02d", i))
+	}
+
+	s := NewServer(nil, nil, nil)
+	fetches := 0
+	fetch := func() ([]string, error) {
+		fetches++
+		return items, nil
+	}
+
+	var got []string
+	c := ""
+	for {
+		args := map[string]any{paramPageSize: 10}
+		if c != "" {
+			args[paramCursor] = c
+		}
+		p, err := paginate(s, request(args), "lines", fetch, identity)
+		if err != nil {
+			t.Fatalf("paginate(...): unexpected error: %v", err)
+		}
+		got = append(slices.Clone(p.Items), got...)
+		if p.Next == "" {
+			break
+		}
+		c = p.Next
+	}
+
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Errorf("paginate(...): paging through every result should return every item exactly once: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(1, fetches); diff != "" {
+		t.Errorf("paginate(...): results should only be fetched for the first page: -want, +got:\n%s", diff)
+	}
+}
+
+func TestPaginateBudget(t *testing.T) {
+	items := make([]string, 0, 100)
+	for i := range 100 {
+		items = append(items, fmt.Sprintf("line-%02d", i))
+	}
+
+	s := NewServer(nil, nil, nil)
+	p, err := paginate(s, request(map[string]any{paramPageSize: 100, paramMaxBytes: 512}), "lines", func() ([]string, error) {
+		return items, nil
+	}, identity)
+	if err != nil {
+		t.Fatalf("paginate(...): unexpected error: %v", err)
+	}
+
+	if len(p.Items) == 0 || len(p.Items) == len(items) {
+		t.Errorf("paginate(...): a page exceeding the budget should be shrunk, got %d items", len(p.Items))
+	}
+	if diff := cmp.Diff(len(items)-len(p.Items), p.Remaining); diff != "" {
+		t.Errorf("paginate(...): items dropped to fit the budget should remain reachable: -want, +got:\n%s", diff)
+	}
+}
+
+func TestPaginateOrigin(t *testing.T) {
+	items := make([]string, 0, 25)
+	for i := range 25 {
+		items = append(items, fmt.Sprintf("line-%02d", i))
+	}
+	call := func(tool string, args map[string]any) mcp.CallToolRequest {
+		req := request(args)
+		req.Params.Name = tool
+		return req
+	}
+
+	cases := map[string]struct {
+		reason string
+		next   mcp.CallToolRequest
+		want   error
+	}{
+		"SameOrigin": {
+			reason: "A cursor should be honoured for the request that produced it.",
+			next:   call("get_pod_logs", map[string]any{"namespace": "default", "pod": "a", paramPageSize: 5}),
+		},
+		"OtherPod": {
+			reason: "A cursor should be rejected for another pod.",
+			next:   call("get_pod_logs", map[string]any{"namespace": "default", "pod": "b"}),
+			want:   errors.New("cursor was returned for a different request, call the tool again without a cursor"),
+		},
+		"OtherNamespace": {
+			reason: "A cursor should be rejected for another namespace.",
+			next:   call("get_pod_logs", map[string]any{"namespace": "kube-system", "pod": "a"}),
+			want:   errors.New("cursor was returned for a different request, call the tool again without a cursor"),
+		},
+		"OtherTool": {
+			reason: "A cursor should be rejected for another tool.",
+			next:   call("get_pod_events", map[string]any{"namespace": "default", "pod": "a"}),
+			want:   errors.New("cursor was returned for a different request, call the tool again without a cursor"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewServer(nil, nil, nil)
+			fetch := func() ([]string, error) { return items, nil }
+
+			first, err := paginate(s, call("get_pod_logs", map[string]any{"namespace": "default", "pod": "a", paramPageSize: 10}), "lines", fetch, identity)
+			if err != nil {
+				t.Fatalf("paginate(...): unexpected error: %v", err)
+			}
+
+			tc.next.Params.Arguments.(map[string]any)[paramCursor] = first.Next
+			_, err = paginate(s, tc.next, "lines", fetch, identity)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npaginate(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPaginateWithRanked(t *testing.T) {
+	// Items ranked first to last, e.g. the largest groups of a sweep first.
+	items := make([]string, 0, 25)
+	for i := range 25 {
+		items = append(items, fmt.Sprintf("rank-%02d", i))
+	}
+
+	s := NewServer(nil, nil, nil)
+	fetches := 0
+	fetch := func() (string, []string, error) {
+		fetches++
+		return "totals", ranked(items), nil
+	}
+
+	var got []string
+	c := ""
+	for {
+		args := map[string]any{paramPageSize: 10}
+		if c != "" {
+			args[paramCursor] = c
+		}
+		h, p, err := paginateWith(s, request(args), "items", fetch, identity)
+		if err != nil {
+			t.Fatalf("paginateWith(...): unexpected error: %v", err)
+		}
+		if diff := cmp.Diff("totals", h); diff != "" {
+			t.Errorf("paginateWith(...): every page should carry the header: -want, +got:\n%s", diff)
+		}
+		got = append(got, p.firstToLast().Items...)
+		if p.Next == "" {
+			break
+		}
+		c = p.Next
+	}
+
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Errorf("paginateWith(...): paging through ranked results should return every item first to last exactly once: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(1, fetches); diff != "" {
+		t.Errorf("paginateWith(...): results should only be fetched for the first page: -want, +got:\n%s", diff)
+	}
+}
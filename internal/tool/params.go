@@ -0,0 +1,126 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"io"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// withObjectRef adds the parameters identifying a single object of any kind
+// to a tool. The description names the kind of object expected.
+func withObjectRef(desc string) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithString("apiVersion",
+			mcp.Required(),
+			mcp.Description("The apiVersion of the "+desc+", e.g. example.org/v1alpha1"),
+		)(t)
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("The kind of the "+desc),
+		)(t)
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the "+desc),
+		)(t)
+		mcp.WithString("namespace",
+			mcp.Description("The namespace of the "+desc+". Omit for cluster scoped objects"),
+		)(t)
+	}
+}
+
+// objectRef returns the kind and name of the object identified by the
+// parameters added by withObjectRef.
+func objectRef(req mcp.CallToolRequest) (schema.GroupVersionKind, types.NamespacedName, error) {
+	av, err := req.RequireString("apiVersion")
+	if err != nil {
+		return schema.GroupVersionKind{}, types.NamespacedName{}, err
+	}
+	kind, err := req.RequireString("kind")
+	if err != nil {
+		return schema.GroupVersionKind{}, types.NamespacedName{}, err
+	}
+	name, err := req.RequireString("name")
+	if err != nil {
+		return schema.GroupVersionKind{}, types.NamespacedName{}, err
+	}
+	gv, err := schema.ParseGroupVersion(av)
+	if err != nil {
+		return schema.GroupVersionKind{}, types.NamespacedName{}, errors.Wrap(err, "invalid apiVersion")
+	}
+	return gv.WithKind(kind), types.NamespacedName{Namespace: req.GetString("namespace", ""), Name: name}, nil
+}
+
+// manifests decodes the YAML or JSON manifests in the named parameter.
+// Multiple YAML documents may be separated by ---. An absent parameter
+// yields no manifests.
+func manifests(req mcp.CallToolRequest, param string) ([]*unstructured.Unstructured, error) {
+	d := yaml.NewYAMLOrJSONDecoder(strings.NewReader(req.GetString(param, "")), 4096)
+	var out []*unstructured.Unstructured
+	for {
+		m := map[string]any{}
+		err := d.Decode(&m)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode %s", param)
+		}
+		if len(m) == 0 {
+			continue
+		}
+		out = append(out, &unstructured.Unstructured{Object: m})
+	}
+}
+
+// manifest decodes the single manifest in the named parameter. It returns
+// nil if the parameter is absent.
+func manifest(req mcp.CallToolRequest, param string) (*unstructured.Unstructured, error) {
+	ms, err := manifests(req, param)
+	if err != nil {
+		return nil, err
+	}
+	switch len(ms) {
+	case 0:
+		return nil, nil
+	case 1:
+		return ms[0], nil
+	default:
+		return nil, errors.Errorf("%s must contain a single manifest, found %d", param, len(ms))
+	}
+}
+
+// objectParam returns the named object parameter, or nil if it is absent.
+func objectParam(req mcp.CallToolRequest, param string) (map[string]any, error) {
+	v, ok := req.GetArguments()[param]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("%s must be an object", param)
+	}
+	return m, nil
+}
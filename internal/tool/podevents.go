@@ -40,9 +40,8 @@ Read the events of the given Kubernetes pod in the given namespace.
 			mcp.Required(),
 			mcp.Description("The name of the Kubernetes pod"),
 		),
-		mcp.WithString("container",
-			mcp.Description("The name of the container of the pod whose logs are being read"),
-		),
+		withPagination(),
+		mcp.WithOutputSchema[PodEvents](),
 	)
 }
 
@@ -62,10 +61,19 @@ func (s *Server) GetPodEventsHander(ctx context.Context, req mcp.CallToolRequest
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	events, err := s.pod.GetEvents(ctx, types.NamespacedName{Namespace: ns, Name: name})
+	p, err := paginate(s, req, "events", func() ([]Event, error) {
+		return s.pod.GetEvents(ctx, types.NamespacedName{Namespace: ns, Name: name})
+	}, renderJSON[Event])
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(string(events)), nil
+	out := PodEvents{
+		Version:   OutputVersion,
+		Namespace: ns,
+		Pod:       name,
+		Events:    p.Items,
+		Page:      p.info(),
+	}
+	return mcp.NewToolResultStructured(out, p.text("events")), nil
 }
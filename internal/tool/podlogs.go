@@ -41,8 +41,10 @@ Read the logs of the given container of the given Kubernetes pod in the given na
 			mcp.Description("The name of the Kubernetes pod"),
 		),
 		mcp.WithString("container",
-			mcp.Description("The name of the container of the pod whose logs are being read"),
+			mcp.Description("The name of the container of the pod whose logs are being read. Required if the pod has more than one container and no default container"),
 		),
+		withPagination(),
+		mcp.WithOutputSchema[PodLogs](),
 	)
 }
 
@@ -62,10 +64,23 @@ func (s *Server) GetPodLogsHander(ctx context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	logs, err := s.pod.GetLogs(ctx, types.NamespacedName{Namespace: ns, Name: name})
+	container := req.GetString("container", "")
+
+	p, err := paginate(s, req, "lines", func() ([]string, error) {
+		logs, err := s.pod.GetLogs(ctx, types.NamespacedName{Namespace: ns, Name: name}, container)
+		return splitLines(logs), err
+	}, func(l string) string { return l })
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(string(logs)), nil
+	out := PodLogs{
+		Version:   OutputVersion,
+		Namespace: ns,
+		Pod:       name,
+		Container: container,
+		Lines:     p.Items,
+		Page:      p.info(),
+	}
+	return mcp.NewToolResultStructured(out, p.text("lines")), nil
 }
@@ -0,0 +1,63 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const checkProviderConfig = "check_provider_config"
+
+// CheckProviderConfig creates a new mcp.Tool for checking the provider
+// config used by a managed resource.
+func CheckProviderConfig() mcp.Tool {
+	return mcp.NewTool(checkProviderConfig,
+		mcp.WithDescription(`
+Check the ProviderConfig used by the given managed resource. Resolves the
+managed resource's providerConfigRef, shows the config's credentials source
+and conditions, checks that every Secret and key it references exists, checks
+that the provider pods have the environment needed for IRSA or Pod Identity
+credentials, counts the ProviderConfigUsages referencing the config and
+returns the config's events. Secret values are never read into the result;
+only whether Secrets and keys exist is reported. If the server isn't allowed
+to read Secrets each Secret reports that its check was skipped.
+`),
+		withObjectRef("managed resource"),
+		mcp.WithOutputSchema[ProviderConfigCheck](),
+	)
+}
+
+// CheckProviderConfigHandler handles tool requests to check the provider
+// config used by a managed resource.
+func (s *Server) CheckProviderConfigHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", checkProviderConfig)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	c, err := s.mr.CheckProviderConfig(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ProviderConfigCheck{Version: OutputVersion, ProviderConfigCheck: *c})
+}
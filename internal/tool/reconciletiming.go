@@ -0,0 +1,63 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const getReconcileTiming = "get_reconcile_timing"
+
+// GetReconcileTiming creates a new mcp.Tool for estimating when a resource
+// was last reconciled and when it will be reconciled next.
+func GetReconcileTiming() mcp.Tool {
+	return mcp.NewTool(getReconcileTiming,
+		mcp.WithDescription(`
+Estimate when the given managed resource or composite resource (XR) was last
+reconciled and when it will be reconciled next. The last reconcile is derived
+from its condition transitions, events and status updates. The next is
+derived from whether it's paused, whether its latest spec change (generation)
+was observed, whether its last reconcile failed and is backing off, and the
+poll interval, sync interval and max reconcile rate flags of the provider or
+Crossplane Deployment that reconciles it. Use it instead of guessing how long
+to wait for a change to take effect.
+`),
+		withObjectRef("managed or composite resource"),
+		mcp.WithOutputSchema[ReconcileTiming](),
+	)
+}
+
+// GetReconcileTimingHandler handles tool requests to estimate the reconcile
+// timing of a resource.
+func (s *Server) GetReconcileTimingHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getReconcileTiming)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	t, err := s.timing.Estimate(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ReconcileTiming{Version: OutputVersion, Timing: *t})
+}
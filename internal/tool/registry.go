@@ -0,0 +1,277 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// Group is a named set of tools that can be enabled or disabled together.
+type Group string
+
+const (
+	// GroupPods contains tools for inspecting pods.
+	GroupPods Group = "pods"
+	// GroupCrossplane contains tools for inspecting Crossplane resources.
+	GroupCrossplane Group = "crossplane"
+	// GroupGeneric contains tools that work against arbitrary Kubernetes
+	// resources.
+	GroupGeneric Group = "generic"
+	// GroupWrite contains tools that modify the controlplane.
+	GroupWrite Group = "write"
+)
+
+// Cost is an estimated class of how expensive a tool is to call, both in
+// terms of load on the controlplane and size of the response.
+type Cost string
+
+const (
+	// CostLow tools issue a handful of requests for a single object.
+	CostLow Cost = "low"
+	// CostMedium tools list objects or read logs within a narrow scope.
+	CostMedium Cost = "medium"
+	// CostHigh tools scan the whole controlplane or run workloads.
+	CostHigh Cost = "high"
+)
+
+// mutatingVerbs are the Kubernetes API verbs that modify the controlplane.
+var mutatingVerbs = map[string]bool{ //nolint:gochecknoglobals // read-only lookup table.
+	"create":           true,
+	"update":           true,
+	"patch":            true,
+	"delete":           true,
+	"deletecollection": true,
+}
+
+// Groups returns all known tool groups.
+func Groups() []Group {
+	return []Group{GroupPods, GroupCrossplane, GroupGeneric, GroupWrite}
+}
+
+// Entry is a tool, its handler and the groups it belongs to.
+type Entry struct {
+	Tool    mcp.Tool
+	Handler server.ToolHandlerFunc
+	Groups  []Group
+
+	// Verbs are the Kubernetes API verbs the tool may issue.
+	Verbs []string
+	// OpenWorld is true if the tool talks to anything other than the
+	// controlplane's API server.
+	OpenWorld bool
+	// Cost is the estimated cost class of calling the tool.
+	Cost Cost
+	// Unavailable explains why the tool can't be used with the server's
+	// configuration. Unavailable tools are never active.
+	Unavailable string
+}
+
+// Name of the underlying tool.
+func (e Entry) Name() string {
+	return e.Tool.Name
+}
+
+// Mutating reports whether the tool may issue a Kubernetes API verb that
+// modifies the controlplane.
+func (e Entry) Mutating() bool {
+	for _, v := range e.Verbs {
+		if mutatingVerbs[v] {
+			return true
+		}
+	}
+	return false
+}
+
+// annotated returns the entry's tool with annotations describing its safety
+// and cost. Read-only tools are idempotent; any tool that may mutate the
+// controlplane is destructive.
+func (e Entry) annotated() mcp.Tool {
+	t := e.Tool
+	mutating := e.Mutating()
+	t.Annotations = mcp.ToolAnnotation{
+		Title:           t.Annotations.Title,
+		ReadOnlyHint:    mcp.ToBoolPtr(!mutating),
+		DestructiveHint: mcp.ToBoolPtr(mutating),
+		IdempotentHint:  mcp.ToBoolPtr(!mutating),
+		OpenWorldHint:   mcp.ToBoolPtr(e.OpenWorld),
+	}
+	if e.Cost != "" {
+		t.Description = fmt.Sprintf("%s\nEstimated cost: %s.\n", strings.TrimRight(t.Description, "\n"), e.Cost)
+	}
+	return t
+}
+
+// in reports whether the entry is selected by the supplied set of tool and
+// group names.
+func (e Entry) in(set map[string]bool) bool {
+	if set[e.Name()] {
+		return true
+	}
+	for _, g := range e.Groups {
+		if set[string(g)] {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry tracks the tools known to the server and which of those are
+// active.
+type Registry struct {
+	entries []Entry
+
+	// enabled tools or groups. An empty set enables every tool.
+	enabled map[string]bool
+	// disabled tools or groups. Disabling takes precedence over enabling.
+	disabled map[string]bool
+}
+
+// RegistryOption modifies the underlying Registry.
+type RegistryOption func(*Registry)
+
+// WithEnabled restricts the active tools to those matching the supplied tool
+// or group names.
+func WithEnabled(names ...string) RegistryOption {
+	return func(r *Registry) {
+		for _, n := range names {
+			r.enabled[n] = true
+		}
+	}
+}
+
+// WithDisabled removes the tools matching the supplied tool or group names
+// from the active set.
+func WithDisabled(names ...string) RegistryOption {
+	return func(r *Registry) {
+		for _, n := range names {
+			r.disabled[n] = true
+		}
+	}
+}
+
+// NewRegistry constructs a new Registry.
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{
+		enabled:  map[string]bool{},
+		disabled: map[string]bool{},
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// Register adds the supplied entries to the Registry.
+func (r *Registry) Register(es ...Entry) {
+	r.entries = append(r.entries, es...)
+}
+
+// Validate that every enabled or disabled name refers to a known tool or
+// group, and that no tool enabled by name is unavailable.
+func (r *Registry) Validate() error {
+	known := map[string]bool{}
+	for _, g := range Groups() {
+		known[string(g)] = true
+	}
+	for _, e := range r.entries {
+		known[e.Name()] = true
+	}
+
+	var unknown []string
+	for _, set := range []map[string]bool{r.enabled, r.disabled} {
+		for n := range set {
+			if !known[n] {
+				unknown = append(unknown, n)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return errors.Errorf("unknown tools or groups: %s", strings.Join(unknown, ", "))
+	}
+
+	var unavailable []string
+	for _, e := range r.entries {
+		if e.Unavailable != "" && r.enabled[e.Name()] {
+			unavailable = append(unavailable, e.Unavailable)
+		}
+	}
+	if len(unavailable) > 0 {
+		return errors.New(strings.Join(unavailable, "; "))
+	}
+	return nil
+}
+
+// Active returns the entries that are enabled and not disabled, in the order
+// they were registered.
+func (r *Registry) Active() []Entry {
+	active := make([]Entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		if e.Unavailable != "" {
+			continue
+		}
+		if len(r.enabled) > 0 && !e.in(r.enabled) {
+			continue
+		}
+		if e.in(r.disabled) {
+			continue
+		}
+		active = append(active, e)
+	}
+	return active
+}
+
+// Tools returns the active tools for registration with an MCP server. Every
+// tool is annotated and accepts an optional response budget which is enforced
+// on its result.
+func (r *Registry) Tools() []server.ServerTool {
+	active := r.Active()
+	ts := make([]server.ServerTool, 0, len(active))
+	for _, e := range active {
+		ts = append(ts, server.ServerTool{Tool: withBudget(e.annotated()), Handler: enforceBudget(e.Handler)})
+	}
+	return ts
+}
+
+// Instructions describes the active tools for inclusion in the server's
+// instructions.
+func (r *Registry) Instructions() string {
+	active := r.Active()
+	if len(active) == 0 {
+		return "No tools are enabled on this server."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("The following tools are enabled on this server:\n")
+	for _, e := range active {
+		groups := make([]string, 0, len(e.Groups))
+		for _, g := range e.Groups {
+			groups = append(groups, string(g))
+		}
+		fmt.Fprintf(&sb, "- %s (groups: %s; cost: %s)\n", e.Name(), strings.Join(groups, ", "), e.Cost)
+	}
+	return sb.String()
+}
@@ -0,0 +1,323 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+func testEntries() []Entry {
+	return []Entry{
+		{Tool: mcp.NewTool("pods_a"), Groups: []Group{GroupPods}},
+		{Tool: mcp.NewTool("pods_b"), Groups: []Group{GroupPods, GroupGeneric}},
+		{Tool: mcp.NewTool("xp_a"), Groups: []Group{GroupCrossplane}},
+		{Tool: mcp.NewTool("xp_write"), Groups: []Group{GroupCrossplane, GroupWrite}},
+		{Tool: mcp.NewTool("xp_off"), Groups: []Group{GroupCrossplane}, Unavailable: "xp_off requires --xp-off"},
+	}
+}
+
+func TestRegistryActive(t *testing.T) {
+	type args struct {
+		opts []RegistryOption
+	}
+	type want struct {
+		names []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AllByDefault": {
+			reason: "If nothing is enabled or disabled, every registered tool should be active.",
+			args:   args{},
+			want: want{
+				names: []string{"pods_a", "pods_b", "xp_a", "xp_write"},
+			},
+		},
+		"EnableGroup": {
+			reason: "Enabling a group should only activate the tools in that group.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("crossplane")},
+			},
+			want: want{
+				names: []string{"xp_a", "xp_write"},
+			},
+		},
+		"EnableGroupAndTool": {
+			reason: "Tools can be enabled by name alongside groups.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("crossplane", "pods_a")},
+			},
+			want: want{
+				names: []string{"pods_a", "xp_a", "xp_write"},
+			},
+		},
+		"DisableGroup": {
+			reason: "Disabling a group should remove every tool that belongs to it.",
+			args: args{
+				opts: []RegistryOption{WithDisabled("write", "generic")},
+			},
+			want: want{
+				names: []string{"pods_a", "xp_a"},
+			},
+		},
+		"DisableWins": {
+			reason: "A disabled tool should not be active even if its group is enabled.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("pods"), WithDisabled("pods_b")},
+			},
+			want: want{
+				names: []string{"pods_a"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewRegistry(tc.args.opts...)
+			r.Register(testEntries()...)
+
+			got := make([]string, 0)
+			for _, e := range r.Active() {
+				got = append(got, e.Name())
+			}
+
+			if diff := cmp.Diff(tc.want.names, got); diff != "" {
+				t.Errorf("\n%s\nActive(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRegistryValidate(t *testing.T) {
+	type args struct {
+		opts []RegistryOption
+	}
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"KnownNames": {
+			reason: "Known tool and group names should be valid.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("pods", "xp_a"), WithDisabled("write")},
+			},
+			want: want{},
+		},
+		"UnknownNames": {
+			reason: "Unknown tool or group names should be reported.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("nope"), WithDisabled("also_nope")},
+			},
+			want: want{
+				err: errors.New("unknown tools or groups: also_nope, nope"),
+			},
+		},
+		"UnavailableTool": {
+			reason: "Enabling an unavailable tool by name should explain why it is unavailable.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("xp_off")},
+			},
+			want: want{
+				err: errors.New("xp_off requires --xp-off"),
+			},
+		},
+		"UnavailableInGroup": {
+			reason: "Enabling a group that contains an unavailable tool should be valid.",
+			args: args{
+				opts: []RegistryOption{WithEnabled("crossplane")},
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewRegistry(tc.args.opts...)
+			r.Register(testEntries()...)
+
+			err := r.Validate()
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEntriesAnnotated(t *testing.T) {
+	entries := append(NewServer(nil, nil, nil, WithRenderFunctionAddresses("localhost:9443")).Entries(),
+		// Guard the check itself with a known mutating tool.
+		Entry{Tool: mcp.NewTool("xp_delete"), Groups: []Group{GroupWrite}, Verbs: []string{"get", "delete"}, Cost: CostLow},
+	)
+
+	for _, e := range entries {
+		t.Run(e.Name(), func(t *testing.T) {
+			if len(e.Verbs) == 0 {
+				t.Errorf("%s: every tool must declare the Kubernetes verbs it issues", e.Name())
+			}
+			if e.Cost == "" {
+				t.Errorf("%s: every tool must declare a cost class", e.Name())
+			}
+
+			mutating := e.Mutating()
+
+			a := e.annotated().Annotations
+			if mutating && !*a.DestructiveHint {
+				t.Errorf("%s: tools issuing mutating verbs %v must be annotated destructive", e.Name(), e.Verbs)
+			}
+			if mutating && !slices.Contains(e.Groups, GroupWrite) {
+				t.Errorf("%s: tools issuing mutating verbs %v must belong to the %q group", e.Name(), e.Verbs, GroupWrite)
+			}
+			if diff := cmp.Diff(!*a.DestructiveHint, *a.ReadOnlyHint); diff != "" {
+				t.Errorf("%s: only non-destructive tools may be read-only: -want, +got:\n%s", e.Name(), diff)
+			}
+		})
+	}
+}
+
+func TestEntriesPodsGroup(t *testing.T) {
+	got := []string{}
+	for _, e := range NewServer(nil, nil, nil, WithRenderFunctionAddresses("localhost:9443")).Entries() {
+		if slices.Contains(e.Groups, GroupPods) {
+			got = append(got, e.Name())
+		}
+	}
+	want := []string{getPodLogs, getPodEvents}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nThe %q group should only expose the pod tools, so that enabling it grants no Crossplane-wide access.\nEntries(): -want, +got:\n%s", GroupPods, diff)
+	}
+}
+
+func TestEntriesRenderUnavailable(t *testing.T) {
+	r := NewRegistry(WithEnabled(renderComposition))
+	r.Register(NewServer(nil, nil, nil).Entries()...)
+
+	want := errors.New("render_composition requires --render-function-addresses")
+	if diff := cmp.Diff(want, r.Validate(), test.EquateErrors()); diff != "" {
+		t.Errorf("\nEnabling render_composition without any function addresses should explain what it requires.\nValidate(...): -want err, +got err:\n%s", diff)
+	}
+}
+
+func TestEntriesVerbs(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "widgets.example.org"},
+		"spec": map[string]any{
+			"group": "example.org",
+			"names": map[string]any{"kind": "Widget", "plural": "widgets"},
+			"scope": "Namespaced",
+			"versions": []any{map[string]any{
+				"name":   "v1",
+				"served": true,
+				"schema": map[string]any{"openAPIV3Schema": map[string]any{
+					"type":                                 "object",
+					"x-kubernetes-preserve-unknown-fields": true,
+				}},
+			}},
+		},
+	}}
+	widget := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]any{"name": "test", "namespace": "default"},
+	}}
+	kinds := []objectfake.Kind{
+		{GVK: schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: "v1", Kind: "Provider"}},
+		{GVK: schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: "v1", Kind: "Function"}},
+		{GVK: schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: "v1", Kind: "Configuration"}},
+		{GVK: schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: "v1beta1", Kind: "Lock"}},
+		{GVK: schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: "v1", Kind: "Composition"}},
+		{GVK: schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: "v1", Kind: "CompositionRevision"}},
+		{GVK: schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: "v1", Kind: "CompositeResourceDefinition"}},
+		{GVK: schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: "v1beta1", Kind: "EnvironmentConfig"}},
+		{GVK: schema.GroupVersionKind{Group: "ops.crossplane.io", Version: "v1alpha1", Kind: "Operation"}},
+		{GVK: schema.GroupVersionKind{Group: "ops.crossplane.io", Version: "v1alpha1", Kind: "CronOperation"}},
+		{GVK: schema.GroupVersionKind{Group: "ops.crossplane.io", Version: "v1alpha1", Kind: "WatchOperation"}},
+		{GVK: schema.GroupVersionKind{Group: "protection.crossplane.io", Version: "v1beta1", Kind: "Usage"}, Namespaced: true},
+		{GVK: schema.GroupVersionKind{Group: "protection.crossplane.io", Version: "v1beta1", Kind: "ClusterUsage"}},
+	}
+	manifest := "apiVersion: example.org/v1\nkind: Widget\nmetadata:\n  name: test\n  namespace: default\n"
+	args := map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "Widget",
+		"name":       "test",
+		"namespace":  "default",
+		"pod":        "test",
+		"group":      "example.org",
+		"manifest":   manifest,
+		"xr":         manifest,
+		"from":       "test-1",
+		"to":         "test-2",
+	}
+
+	for _, e := range NewServer(nil, nil, nil, WithRenderFunctionAddresses("localhost:9443")).Entries() {
+		t.Run(e.Name(), func(t *testing.T) {
+			issued := map[string]bool{}
+			record := func(a k8stesting.Action) (bool, runtime.Object, error) {
+				issued[a.GetVerb()] = true
+				return false, nil, nil
+			}
+
+			cs := kfake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}})
+			cs.PrependReactor("*", "*", record)
+			objs := []*unstructured.Unstructured{crd, widget}
+			dyn := objectfake.Dynamic(kinds, objs)
+			dyn.PrependReactor("*", "*", record)
+
+			s := NewServer(cs, dyn, objectfake.Mapper(append(kinds, objectfake.KindsOf(objs)...)...), WithRenderFunctionAddresses("localhost:9443"))
+			for _, h := range s.Entries() {
+				if h.Name() == e.Name() {
+					_, _ = h.Handler(context.Background(), request(args))
+				}
+			}
+
+			for v := range issued {
+				if !slices.Contains(e.Verbs, v) {
+					t.Errorf("%s: handler issued the %q verb but only declares %v", e.Name(), v, e.Verbs)
+				}
+			}
+			if e.Mutating() && len(issued) == 0 {
+				t.Errorf("%s: mutating handler issued no verbs; the fixture no longer exercises it", e.Name())
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/controlplane-mcp-server/internal/render"
+)
+
+const renderComposition = "render_composition"
+
+// RenderComposition creates a new mcp.Tool for rendering a composite
+// resource through its Composition's function pipeline.
+func RenderComposition() mcp.Tool {
+	return mcp.NewTool(renderComposition,
+		mcp.WithDescription(`
+Render a composite resource (XR) like 'crossplane render': run the pipeline of
+a Pipeline mode Composition against the XR and return the desired composed
+resources, the XR's desired status and each step's results. Nothing is
+applied to the control plane. Functions are not started; each function
+referenced by the pipeline must already be listening at the address supplied
+in 'functions', for example one started with 'go run . --insecure --debug'.
+Only addresses the server's operator allowed may be used.
+Extra resources requested by functions are read from the control plane; they
+must be cluster scoped and can't be Secrets.
+Step credentials are not supplied.
+`),
+		mcp.WithString("xr",
+			mcp.Required(),
+			mcp.Description("The YAML or JSON manifest of the composite resource to render"),
+		),
+		mcp.WithString("composition",
+			mcp.Description("The YAML or JSON manifest of the Composition. Defaults to the Composition or CompositionRevision the XR references in the control plane"),
+		),
+		mcp.WithObject("functions",
+			mcp.Required(),
+			mcp.Description("Map of Function name, as referenced by the pipeline's functionRef, to the gRPC address it listens on, e.g. {\"function-patch-and-transform\": \"localhost:9443\"}"),
+			mcp.AdditionalProperties(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("observedResources",
+			mcp.Description("YAML or JSON manifests, separated by ---, of composed resources that already exist. Each must have the crossplane.io/composition-resource-name annotation"),
+		),
+		mcp.WithObject("context",
+			mcp.Description("The initial pipeline context, e.g. to supply an environment under apiextensions.crossplane.io/environment"),
+		),
+		mcp.WithOutputSchema[CompositionRender](),
+	)
+}
+
+// RenderCompositionHandler handles tool requests to render a composite
+// resource.
+func (s *Server) RenderCompositionHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", renderComposition)
+	log.Debug("received request")
+
+	in, fns, err := renderInputs(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if in.Composition == nil {
+		if in.Composition, err = s.comp.Selected(ctx, in.CompositeResource); err != nil {
+			return mcp.NewToolResultError(errors.Wrap(err, "cannot resolve the Composition; supply it in the composition parameter").Error()), nil
+		}
+	}
+
+	run := render.NewDevelopmentRunner(fns, render.WithAllowedAddresses(s.renderAddresses...))
+	r := render.New(run, render.WithFetcher(render.NewObjectFetcher(s.obj)), render.WithLogger(log))
+	out, err := r.Render(ctx, in)
+	if out == nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// A failed pipeline still renders the results that explain the failure.
+	return structured(CompositionRender{Version: OutputVersion, Outputs: *out})
+}
+
+// renderInputs returns the inputs of a render and the address of each
+// function from the supplied request.
+func renderInputs(req mcp.CallToolRequest) (render.Inputs, map[string]string, error) {
+	xr, err := manifest(req, "xr")
+	if err != nil {
+		return render.Inputs{}, nil, err
+	}
+	if xr == nil {
+		return render.Inputs{}, nil, errors.New("xr is required")
+	}
+	comp, err := manifest(req, "composition")
+	if err != nil {
+		return render.Inputs{}, nil, err
+	}
+	observed, err := manifests(req, "observedResources")
+	if err != nil {
+		return render.Inputs{}, nil, err
+	}
+	fctx, err := objectParam(req, "context")
+	if err != nil {
+		return render.Inputs{}, nil, err
+	}
+	raw, err := objectParam(req, "functions")
+	if err != nil {
+		return render.Inputs{}, nil, err
+	}
+	fns := make(map[string]string, len(raw))
+	for name, v := range raw {
+		addr, ok := v.(string)
+		if !ok {
+			return render.Inputs{}, nil, errors.Errorf("address of function %s must be a string", name)
+		}
+		fns[name] = addr
+	}
+
+	in := render.Inputs{
+		CompositeResource: xr,
+		Composition:       comp,
+		ObservedResources: observed,
+		Context:           fctx,
+	}
+	return in, fns, nil
+}
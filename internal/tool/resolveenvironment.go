@@ -0,0 +1,62 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const resolveEnvironment = "resolve_environment"
+
+// ResolveEnvironment creates a new mcp.Tool for resolving the
+// EnvironmentConfigs selected for a composite resource.
+func ResolveEnvironment() mcp.Tool {
+	return mcp.NewTool(resolveEnvironment,
+		mcp.WithDescription(`
+Resolve the environment of the given composite resource (XR). Finds where the
+XR's Composition selects EnvironmentConfigs, either its spec.environment or
+the input of a pipeline step such as function-environment-configs, and
+resolves each selection by reference or label the way Crossplane would. Returns
+the EnvironmentConfigs selected, their data in merge order, the merged
+environment and any selection errors. Useful to explain unexpected values
+patched from the environment.
+`),
+		withObjectRef("composite resource"),
+		mcp.WithOutputSchema[EnvironmentResolution](),
+	)
+}
+
+// ResolveEnvironmentHandler handles tool requests to resolve the
+// environment of a composite resource.
+func (s *Server) ResolveEnvironmentHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", resolveEnvironment)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	r, err := s.env.Resolve(ctx, gvk, nn)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(EnvironmentResolution{Version: OutputVersion, Resolution: *r})
+}
@@ -0,0 +1,69 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/graph"
+)
+
+const getResourceGraph = "get_resource_graph"
+
+// GetResourceGraph creates a new mcp.Tool for building the graph of
+// resources a resource is connected to.
+func GetResourceGraph() mcp.Tool {
+	return mcp.NewTool(getResourceGraph,
+		mcp.WithDescription(`
+Build the graph of resources the given resource is connected to, starting from
+it and following owner references, the composed resources of composite
+resources (XRs), claim bindings, providerConfigRefs, cross-resource references
+in *Ref, *Refs, *Selector and *Selectors fields of managed resources, and
+Usages. Returns the nodes, with their Ready and Synced status or whether
+they're missing, and the typed edges between them, along with a Graphviz DOT
+or Mermaid diagram in which unhealthy resources are highlighted. Use it to see
+how a failing resource connects to the rest of the controlplane.
+`),
+		withObjectRef("resource"),
+		mcp.WithString("format",
+			mcp.Description("The format of the diagram. Defaults to mermaid"),
+			mcp.Enum(graph.Formats()...),
+		),
+		mcp.WithOutputSchema[ResourceGraph](),
+	)
+}
+
+// GetResourceGraphHandler handles tool requests to build the graph of
+// resources a resource is connected to.
+func (s *Server) GetResourceGraphHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", getResourceGraph)
+	log.Debug("received request")
+
+	gvk, nn, err := objectRef(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	g, err := s.graph.Build(ctx, gvk, nn, req.GetString("format", graph.FormatMermaid))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return structured(ResourceGraph{Version: OutputVersion, Graph: *g})
+}
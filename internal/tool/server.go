@@ -17,19 +17,75 @@
 package tool
 
 import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	"github.com/upbound/controlplane-mcp-server/internal/cursor"
+	"github.com/upbound/controlplane-mcp-server/internal/explain"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/claim"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/composition"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/connection"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/core"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/deletion"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/environment"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/fleet"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/graph"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/managed"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/operation"
 	"github.com/upbound/controlplane-mcp-server/internal/resource/pod"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/timing"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/xpkg"
+	"github.com/upbound/controlplane-mcp-server/internal/validate"
+)
+
+const (
+	// snapshotLogLines is the number of log lines read from a pod and made
+	// available for paging.
+	snapshotLogLines = 1000
+	// snapshotEvents is the number of events read for a pod and made
+	// available for paging.
+	snapshotEvents = 100
+
+	// runtimeLogLines is the number of log lines read from each pod running
+	// a package.
+	runtimeLogLines = 20
+	// coreLogLines is the number of log lines scanned for errors from each
+	// pod running Crossplane.
+	coreLogLines = 500
 )
 
 // Server is a simple server for handling various tooling requests.
 type Server struct {
-	c   *kubernetes.Clientset
+	c   kubernetes.Interface
 	log logging.Logger
 
-	pod *pod.Pod
+	pod     *pod.Pod
+	obj     *object.Client
+	pkg     *xpkg.Packages
+	comp    *composition.Compositions
+	val     *validate.Validator
+	exp     *explain.Explainer
+	mr      *managed.Resources
+	ops     *operation.Operations
+	conn    *connection.Connections
+	del     *deletion.Explainer
+	env     *environment.Resolver
+	fleet   *fleet.Fleet
+	claims  *claim.Claims
+	core    *core.Core
+	timing  *timing.Estimator
+	graph   *graph.Builder
+	cursors *cursor.Store
+
+	// renderAddresses are the function addresses render_composition may
+	// dial.
+	renderAddresses []string
 }
 
 // Option modifies the underlying Server.
@@ -42,17 +98,240 @@ func WithLogging(log logging.Logger) Option {
 	}
 }
 
-// NewServer constructs a new Server.
-func NewServer(c *kubernetes.Clientset, opts ...Option) *Server {
+// WithRenderFunctionAddresses allows render_composition to run functions at
+// the supplied addresses. The tool is unavailable unless at least one address
+// is allowed, because it sends composite and extra resources read with the
+// server's permissions to those addresses.
+func WithRenderFunctionAddresses(addrs ...string) Option {
+	return func(s *Server) {
+		s.renderAddresses = addrs
+	}
+}
+
+// WithCursorStore overrides the store used to hold paginated results.
+func WithCursorStore(cs *cursor.Store) Option {
+	return func(s *Server) {
+		s.cursors = cs
+	}
+}
+
+// NewServer constructs a new Server. The dynamic client and RESTMapper are
+// used to read Crossplane and other custom resources.
+func NewServer(c kubernetes.Interface, dyn dynamic.Interface, mapper meta.RESTMapper, opts ...Option) *Server {
 	s := &Server{
-		c:   c,
-		pod: pod.New(c),
-		log: logging.NewNopLogger(),
+		c:       c,
+		pod:     pod.New(c, pod.WithMaxLogLines(snapshotLogLines), pod.WithMaxEvents(snapshotEvents)),
+		obj:     object.New(c, dyn, mapper),
+		cursors: cursor.NewStore(),
+		log:     logging.NewNopLogger(),
 	}
 
 	for _, o := range opts {
 		o(s)
 	}
 
+	s.pkg = xpkg.New(s.obj, pod.New(c, pod.WithLogger(s.log), pod.WithMaxLogLines(runtimeLogLines)), xpkg.WithLogger(s.log))
+	s.comp = composition.New(s.obj, composition.WithLogger(s.log))
+	s.val = validate.New(s.obj, validate.WithLogger(s.log))
+	s.exp = explain.New(s.obj, explain.WithLogger(s.log))
+	s.mr = managed.New(s.obj, s.pkg, managed.WithLogger(s.log))
+	s.ops = operation.New(s.obj, operation.WithLogger(s.log))
+	s.conn = connection.New(s.obj, connection.WithLogger(s.log))
+	s.del = deletion.New(s.obj, deletion.WithLogger(s.log))
+	s.env = environment.New(s.obj, s.comp, environment.WithLogger(s.log))
+	s.fleet = fleet.New(s.obj, fleet.WithLogger(s.log))
+	s.claims = claim.New(s.obj, s.conn, claim.WithLogger(s.log))
+	s.core = core.New(c, pod.New(c, pod.WithLogger(s.log), pod.WithMaxLogLines(coreLogLines)), core.WithLogger(s.log))
+	s.timing = timing.New(s.obj, s.mr, timing.WithLogger(s.log))
+	s.graph = graph.New(s.obj, graph.WithLogger(s.log))
+
 	return s
 }
+
+// Entries returns every tool the Server can handle along with the groups
+// each tool belongs to and the Kubernetes verbs it may issue.
+func (s *Server) Entries() []Entry {
+	entries := []Entry{
+		{
+			Tool:    GetPodLogs(),
+			Handler: s.GetPodLogsHander,
+			Groups:  []Group{GroupPods},
+			Verbs:   []string{"get"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    GetPodEvents(),
+			Handler: s.GetPodEventsHander,
+			Groups:  []Group{GroupPods},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    GetPackageStatus(),
+			Handler: s.GetPackageStatusHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    InspectComposition(),
+			Handler: s.InspectCompositionHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:      RenderComposition(),
+			Handler:   s.RenderCompositionHandler,
+			Groups:    []Group{GroupCrossplane},
+			Verbs:     []string{"get", "list"},
+			OpenWorld: true,
+			Cost:      CostHigh,
+		},
+		{
+			Tool:    ValidateManifest(),
+			Handler: s.ValidateManifestHandler,
+			Groups:  []Group{GroupGeneric, GroupCrossplane},
+			Verbs:   []string{"get"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    DryRunManifest(),
+			Handler: s.DryRunManifestHandler,
+			Groups:  []Group{GroupWrite},
+			Verbs:   []string{"get", "create"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    ExplainAPI(),
+			Handler: s.ExplainAPIHandler,
+			Groups:  []Group{GroupGeneric, GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    GetManagedResourceLogs(),
+			Handler: s.GetManagedResourceLogsHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    ListOperations(),
+			Handler: s.ListOperationsHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"list"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    GetOperation(),
+			Handler: s.GetOperationHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    FindOperationsForResource(),
+			Handler: s.FindOperationsForResourceHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    CheckProviderConfig(),
+			Handler: s.CheckProviderConfigHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    GetConnectionDetails(),
+			Handler: s.GetConnectionDetailsHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    ExplainDeletion(),
+			Handler: s.ExplainDeletionHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    ResolveEnvironment(),
+			Handler: s.ResolveEnvironmentHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    DiffCompositionRevisions(),
+			Handler: s.DiffCompositionRevisionsHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    GetDrift(),
+			Handler: s.GetDriftHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    FindUnhealthy(),
+			Handler: s.FindUnhealthyHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"list"},
+			Cost:    CostHigh,
+		},
+		{
+			Tool:    GetFunctionStatus(),
+			Handler: s.GetFunctionStatusHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostHigh,
+		},
+		{
+			Tool:    FindManagementOverrides(),
+			Handler: s.FindManagementOverridesHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"list"},
+			Cost:    CostHigh,
+		},
+		{
+			Tool:    DescribeClaim(),
+			Handler: s.DescribeClaimHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    GetCrossplaneHealth(),
+			Handler: s.GetCrossplaneHealthHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostMedium,
+		},
+		{
+			Tool:    GetReconcileTiming(),
+			Handler: s.GetReconcileTimingHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostLow,
+		},
+		{
+			Tool:    GetResourceGraph(),
+			Handler: s.GetResourceGraphHandler,
+			Groups:  []Group{GroupCrossplane},
+			Verbs:   []string{"get", "list"},
+			Cost:    CostHigh,
+		},
+	}
+	if len(s.renderAddresses) == 0 {
+		i := slices.IndexFunc(entries, func(e Entry) bool { return e.Name() == renderComposition })
+		entries[i].Unavailable = renderComposition + " requires --render-function-addresses"
+	}
+	return entries
+}
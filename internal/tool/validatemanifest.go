@@ -0,0 +1,87 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package tool
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/upbound/controlplane-mcp-server/internal/validate"
+)
+
+const validateManifest = "validate_manifest"
+
+// ValidateManifest creates a new mcp.Tool for validating manifests against
+// the schemas installed in the controlplane.
+func ValidateManifest() mcp.Tool {
+	return mcp.NewTool(validateManifest,
+		mcp.WithDescription(`
+Validate manifests, such as claims and composite resources, against the
+CustomResourceDefinitions installed in the controlplane, including those
+generated from Crossplane CompositeResourceDefinitions (XRDs). Runs OpenAPI v3
+structural schema validation and CEL x-kubernetes-validations rules the way
+the API server would and returns errors with precise field paths, plus any
+unknown fields the API server would prune. Nothing is written to the
+controlplane.
+`),
+		mcp.WithString("manifest",
+			mcp.Required(),
+			mcp.Description("The YAML or JSON manifests to validate. Multiple YAML documents may be separated by ---"),
+		),
+		mcp.WithOutputSchema[ManifestValidation](),
+	)
+}
+
+// ValidateManifestHandler handles tool requests to validate manifests.
+func (s *Server) ValidateManifestHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log := s.log.WithValues("handler", validateManifest)
+	log.Debug("received request")
+
+	return s.validateManifests(ctx, req, false)
+}
+
+// validateManifests validates the manifests of the supplied request,
+// optionally sending each to the API server as a dry-run create.
+func (s *Server) validateManifests(ctx context.Context, req mcp.CallToolRequest, dryRun bool) (*mcp.CallToolResult, error) {
+	ms, err := manifests(req, "manifest")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(ms) == 0 {
+		return mcp.NewToolResultError("manifest contains no objects"), nil
+	}
+
+	out := ManifestValidation{Version: OutputVersion, Valid: true, Results: make([]validate.Result, 0, len(ms))}
+	for _, u := range ms {
+		r, err := s.val.Validate(ctx, u, dryRun)
+		if err != nil {
+			r = &validate.Result{
+				APIVersion: u.GetAPIVersion(),
+				Kind:       u.GetKind(),
+				Namespace:  u.GetNamespace(),
+				Name:       u.GetName(),
+				Errors:     []validate.FieldError{},
+				Error:      err.Error(),
+			}
+		}
+		out.Valid = out.Valid && r.Valid
+		out.Results = append(out.Results, *r)
+	}
+
+	return structured(out)
+}
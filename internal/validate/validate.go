@@ -0,0 +1,231 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Package validate validates manifests against the schemas of the
+CustomResourceDefinitions installed in the controlplane, including those
+generated for Crossplane CompositeResourceDefinitions, without writing to
+the controlplane.
+*/
+package validate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	"github.com/upbound/controlplane-mcp-server/internal/resource/object"
+)
+
+// Result is the result of validating a manifest.
+type Result struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+
+	// CRD whose schema the manifest was validated against.
+	CRD string `json:"crd,omitempty"`
+	// DefinedBy is the CompositeResourceDefinition that owns the CRD, if any.
+	DefinedBy string `json:"definedBy,omitempty"`
+
+	Valid  bool         `json:"valid"`
+	Errors []FieldError `json:"errors"`
+	// UnknownFields would be pruned by the API server.
+	UnknownFields []string `json:"unknownFields,omitempty"`
+	DryRun        *DryRun  `json:"dryRun,omitempty"`
+
+	// Error explains why the manifest could not be validated at all.
+	Error string `json:"error,omitempty"`
+}
+
+// FieldError is an error at a particular field of a manifest.
+type FieldError struct {
+	Field  string `json:"field"`
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+}
+
+// DryRun is the outcome of a server-side dry-run create.
+type DryRun struct {
+	Accepted bool         `json:"accepted"`
+	Error    string       `json:"error,omitempty"`
+	Causes   []FieldError `json:"causes,omitempty"`
+}
+
+// Validator validates manifests against installed schemas.
+type Validator struct {
+	log logging.Logger
+	obj *object.Client
+}
+
+// Option modifies the underlying Validator.
+type Option func(*Validator)
+
+// WithLogger overrides the default logger.
+func WithLogger(log logging.Logger) Option {
+	return func(v *Validator) {
+		v.log = log
+	}
+}
+
+// New constructs a new Validator.
+func New(obj *object.Client, opts ...Option) *Validator {
+	v := &Validator{
+		log: logging.NewNopLogger(),
+		obj: obj,
+	}
+
+	for _, o := range opts {
+		o(v)
+	}
+
+	return v
+}
+
+// Validate the supplied manifest against the schema of its CRD, the same way
+// the API server would: unknown fields are pruned, defaults applied, then
+// the OpenAPI v3 schema and CEL validation rules are checked. If dryRun is
+// true the manifest is also sent to the API server as a dry-run create,
+// which catches anything else admission would reject.
+func (v *Validator) Validate(ctx context.Context, u *unstructured.Unstructured, dryRun bool) (*Result, error) {
+	r := &Result{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+		Errors:     []FieldError{},
+	}
+	if u.GetName() == "" && u.GetGenerateName() == "" {
+		r.Errors = append(r.Errors, FieldError{Field: "metadata.name", Type: string(field.ErrorTypeRequired), Detail: "name or generateName is required"})
+	}
+
+	c, err := crd.ForKind(ctx, v.obj, u.GroupVersionKind())
+	if err != nil {
+		return nil, errors.Wrap(err, "only custom resources can be validated")
+	}
+	r.CRD = c.GetName()
+	r.DefinedBy = crd.DefinedBy(c)
+
+	s, err := versionSchema(c, u.GroupVersionKind().Version)
+	if err != nil {
+		return nil, err
+	}
+	if u.GetNamespace() != "" && c.Spec.Scope == extv1.ClusterScoped {
+		r.Errors = append(r.Errors, FieldError{Field: "metadata.namespace", Type: string(field.ErrorTypeForbidden), Detail: fmt.Sprintf("%s is cluster scoped", u.GetKind())})
+	}
+	errs, unknown, err := validate(ctx, u.DeepCopy().Object, s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot validate against the schema of CustomResourceDefinition %s", c.GetName())
+	}
+	r.Errors = append(r.Errors, errs...)
+	r.UnknownFields = unknown
+
+	if dryRun {
+		r.DryRun = v.dryRun(ctx, u)
+	}
+
+	r.Valid = len(r.Errors) == 0 && (r.DryRun == nil || r.DryRun.Accepted)
+	return r, nil
+}
+
+// versionSchema returns the schema of the supplied version of the CRD.
+func versionSchema(c *extv1.CustomResourceDefinition, version string) (*apiextensions.JSONSchemaProps, error) {
+	v, err := crd.Version(c, version)
+	if err != nil {
+		return nil, err
+	}
+	if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+		return nil, errors.Errorf("version %s of CustomResourceDefinition %s has no schema", version, c.GetName())
+	}
+	s := &apiextensions.JSONSchemaProps{}
+	if err := extv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(v.Schema.OpenAPIV3Schema, s, nil); err != nil {
+		return nil, errors.Wrap(err, "cannot convert schema")
+	}
+	return s, nil
+}
+
+// validate the supplied object against the supplied schema. The object is
+// pruned and defaulted in place.
+func validate(ctx context.Context, obj map[string]any, s *apiextensions.JSONSchemaProps) ([]FieldError, []string, error) {
+	ss, err := structuralschema.NewStructural(s)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "schema is not structural")
+	}
+
+	unknown := pruning.PruneWithOptions(obj, ss, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+	slices.Sort(unknown)
+	defaulting.Default(obj, ss)
+
+	sv, _, err := validation.NewSchemaValidator(s)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot build schema validator")
+	}
+	errs := validation.ValidateCustomResource(nil, obj, sv)
+
+	// CEL rules are only evaluated against objects that are valid otherwise.
+	if len(errs) == 0 {
+		if cv := cel.NewValidator(ss, true, celconfig.PerCallLimit); cv != nil {
+			cerrs, _ := cv.Validate(ctx, nil, ss, obj, nil, celconfig.RuntimeCELCostBudget)
+			errs = append(errs, cerrs...)
+		}
+	}
+
+	return fieldErrors(errs), unknown, nil
+}
+
+// fieldErrors converts a field.ErrorList, ordered by field path.
+func fieldErrors(errs field.ErrorList) []FieldError {
+	out := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, FieldError{Field: e.Field, Type: string(e.Type), Detail: e.ErrorBody()})
+	}
+	slices.SortStableFunc(out, func(a, b FieldError) int { return strings.Compare(a.Field, b.Field) })
+	return out
+}
+
+// dryRun creates the supplied object without persisting it.
+func (v *Validator) dryRun(ctx context.Context, u *unstructured.Unstructured) *DryRun {
+	_, err := v.obj.DryRunCreate(ctx, u)
+	if err == nil {
+		return &DryRun{Accepted: true}
+	}
+	d := &DryRun{Error: err.Error()}
+	var status kerrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, c := range status.Status().Details.Causes {
+			d.Causes = append(d.Causes, FieldError{Field: c.Field, Type: string(c.Type), Detail: c.Message})
+		}
+	}
+	return d
+}
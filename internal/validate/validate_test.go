@@ -0,0 +1,204 @@
+// /*
+// Copyright 2025 The Upbound Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/upbound/controlplane-mcp-server/internal/resource/crd"
+	objectfake "github.com/upbound/controlplane-mcp-server/internal/resource/object/fake"
+)
+
+var xbucketGVK = schema.GroupVersionKind{Group: "example.org", Version: "v1alpha1", Kind: "XBucket"}
+
+func xbucketCRD() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]any{
+			"name": "xbuckets.example.org",
+			"ownerReferences": []any{map[string]any{
+				"apiVersion": "apiextensions.crossplane.io/v1",
+				"kind":       "CompositeResourceDefinition",
+				"name":       "xbuckets.example.org",
+				"uid":        "xrd-uid",
+			}},
+		},
+		"spec": map[string]any{
+			"group": "example.org",
+			"names": map[string]any{"kind": "XBucket", "plural": "xbuckets"},
+			"scope": "Cluster",
+			"versions": []any{map[string]any{
+				"name":   "v1alpha1",
+				"served": true,
+				"schema": map[string]any{"openAPIV3Schema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"spec": map[string]any{
+							"type":     "object",
+							"required": []any{"region"},
+							"properties": map[string]any{
+								"region":  map[string]any{"type": "string"},
+								"size":    map[string]any{"type": "integer", "minimum": int64(1), "default": int64(1)},
+								"maxSize": map[string]any{"type": "integer", "default": int64(10)},
+							},
+							"x-kubernetes-validations": []any{map[string]any{
+								"rule":    "self.size <= self.maxSize",
+								"message": "size must not exceed maxSize",
+							}},
+						},
+					},
+				}},
+			}},
+		},
+	}}
+}
+
+func bucket(spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1alpha1",
+		"kind":       "XBucket",
+		"metadata":   map[string]any{"name": "bucket"},
+		"spec":       spec,
+	}}
+}
+
+func TestValidate(t *testing.T) {
+	type args struct {
+		u      *unstructured.Unstructured
+		dryRun bool
+		crds   []*unstructured.Unstructured
+	}
+	type want struct {
+		r   *Result
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Valid": {
+			reason: "A valid manifest should pass, with unknown fields reported and defaults applied before CEL rules run.",
+			args: args{
+				u:    bucket(map[string]any{"region": "us-east-1", "colour": "blue"}),
+				crds: []*unstructured.Unstructured{xbucketCRD()},
+			},
+			want: want{
+				r: &Result{
+					APIVersion:    "example.org/v1alpha1",
+					Kind:          "XBucket",
+					Name:          "bucket",
+					CRD:           "xbuckets.example.org",
+					DefinedBy:     "xbuckets.example.org",
+					Valid:         true,
+					Errors:        []FieldError{},
+					UnknownFields: []string{"spec.colour"},
+				},
+			},
+		},
+		"SchemaErrors": {
+			reason: "Violations of the OpenAPI schema should be reported with their field paths.",
+			args: args{
+				u:    bucket(map[string]any{"size": int64(0)}),
+				crds: []*unstructured.Unstructured{xbucketCRD()},
+			},
+			want: want{
+				r: &Result{
+					APIVersion: "example.org/v1alpha1",
+					Kind:       "XBucket",
+					Name:       "bucket",
+					CRD:        "xbuckets.example.org",
+					DefinedBy:  "xbuckets.example.org",
+					Errors: []FieldError{
+						{Field: "spec.region", Type: "FieldValueRequired", Detail: "Required value"},
+						{Field: "spec.size", Type: "FieldValueInvalid", Detail: "Invalid value: 0: spec.size in body should be greater than or equal to 1"},
+					},
+				},
+			},
+		},
+		"CELErrors": {
+			reason: "Violations of x-kubernetes-validations rules should be reported with their field paths.",
+			args: args{
+				u:    bucket(map[string]any{"region": "us-east-1", "size": int64(20)}),
+				crds: []*unstructured.Unstructured{xbucketCRD()},
+			},
+			want: want{
+				r: &Result{
+					APIVersion: "example.org/v1alpha1",
+					Kind:       "XBucket",
+					Name:       "bucket",
+					CRD:        "xbuckets.example.org",
+					DefinedBy:  "xbuckets.example.org",
+					Errors: []FieldError{
+						{Field: "spec", Type: "FieldValueInvalid", Detail: `Invalid value: "object": size must not exceed maxSize`},
+					},
+				},
+			},
+		},
+		"DryRun": {
+			reason: "A dry-run create should be reported when requested.",
+			args: args{
+				u:      bucket(map[string]any{"region": "us-east-1"}),
+				dryRun: true,
+				crds:   []*unstructured.Unstructured{xbucketCRD()},
+			},
+			want: want{
+				r: &Result{
+					APIVersion: "example.org/v1alpha1",
+					Kind:       "XBucket",
+					Name:       "bucket",
+					CRD:        "xbuckets.example.org",
+					DefinedBy:  "xbuckets.example.org",
+					Valid:      true,
+					Errors:     []FieldError{},
+					DryRun:     &DryRun{Accepted: true},
+				},
+			},
+		},
+		"NoCRD": {
+			reason: "Kinds that aren't defined by a CRD cannot be validated.",
+			args: args{
+				u: bucket(map[string]any{}),
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := New(objectfake.NewClient([]objectfake.Kind{{GVK: xbucketGVK}, {GVK: crd.GVK()}}, tc.args.crds))
+
+			got, err := v.Validate(context.Background(), tc.args.u, tc.args.dryRun)
+
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want err, +got err:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}